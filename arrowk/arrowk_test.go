@@ -0,0 +1,96 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package arrowk_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/fogfish/guid/arrowk"
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestArray(t *testing.T) {
+	ids := []guid.K{guid.G(guid.Clock), guid.L(guid.Clock), guid.Nil(), guid.G(guid.Clock)}
+
+	arr := arrowk.NewArray(ids)
+	defer arr.Release()
+
+	x, err := arrowk.Values(arr)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(arr.Len(), len(ids)),
+		it.Seq(x).Equal(ids...),
+	)
+}
+
+func TestBuilder(t *testing.T) {
+	g := guid.G(guid.Clock)
+
+	b := array.NewFixedSizeBinaryBuilder(memory.DefaultAllocator, arrowk.Type)
+	defer b.Release()
+	arrowk.Append(b, g)
+	b.AppendNull()
+
+	arr := b.NewFixedSizeBinaryArray()
+	defer arr.Release()
+
+	it.Then(t).Should(
+		it.Equal(arrowk.Value(arr, 0), g),
+		it.Equal(arrowk.Value(arr, 1), guid.Nil()),
+	)
+
+	other := array.NewFixedSizeBinaryBuilder(memory.DefaultAllocator, &arrow.FixedSizeBinaryType{ByteWidth: 8})
+	defer other.Release()
+	_, err := arrowk.Values(other.NewFixedSizeBinaryArray())
+	it.Then(t).ShouldNot(it.Nil(err))
+}
+
+func TestIPC(t *testing.T) {
+	ids := []guid.K{guid.G(guid.Clock), guid.G(guid.Clock), guid.L(guid.Clock)}
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrowk.Type}}, nil)
+
+	arr := arrowk.NewArray(ids)
+	rec := array.NewRecord(schema, []arrow.Array{arr}, int64(len(ids)))
+	arr.Release()
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(schema))
+	it.Then(t).Should(
+		it.Nil(w.Write(rec)),
+		it.Nil(w.Close()),
+	)
+
+	r, err := ipc.NewReader(&buf)
+	it.Then(t).Should(it.Nil(err))
+	defer r.Release()
+
+	it.Then(t).Should(it.True(r.Next()))
+	x, err := arrowk.Values(r.Record().Column(0).(*array.FixedSizeBinary))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Seq(x).Equal(ids...),
+	)
+}
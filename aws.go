@@ -0,0 +1,63 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"math/big"
+)
+
+// DeduplicationID encodes k-ordered value as SQS FIFO MessageDeduplicationId,
+// which is at most 128 chars of alphanumeric and punctuation characters. The
+// encoding is deterministic, global values are lexicographically sortable
+// string and local values are canonical local string (see StringLocal), so
+// that retries from any process produce the same id. Do not truncate the
+// value, ⟨𝒔⟩ and ⟨𝒍⟩ fractions are at the end of the string.
+func DeduplicationID(uid K) string {
+	return stableString(uid)
+}
+
+// stableString encodes k-ordered value to text without promotion of local
+// values, the encoding does not depend on the process.
+func stableString(uid K) string {
+	switch {
+	case uid == Nil():
+		return ""
+	case uid.Hi == 0:
+		return StringLocal(uid)
+	default:
+		var buf [16]byte
+		return string(appendString(buf[:0], uid))
+	}
+}
+
+// SequenceForKinesis encodes k-ordered value as decimal string compliant with
+// Kinesis sequence number format 0|[1-9][0-9]{0,128}. The numeric order of
+// values is consistent with k-order. Class and delete-marker bits are
+// dropped, same as binary encoding does (see WithClass and TombstoneOf).
+//
+// Kinesis guarantees strict ordering only if SequenceNumberForOrdering is
+// the sequence number assigned by Kinesis to the previous record of the same
+// partition key. Use the value for ordering at consumers (e.g. record
+// attribute), not as SequenceNumberForOrdering of unrelated records.
+func SequenceForKinesis(uid K) string {
+	x := new(big.Int).SetUint64(uid.Hi & 0xffffffff)
+	x.Lsh(x, 64)
+	x.Or(x, new(big.Int).SetUint64(uid.Lo))
+	return x.String()
+}
@@ -0,0 +1,62 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestDeduplicationID(t *testing.T) {
+	sqs := regexp.MustCompile("^[[:alnum:][:punct:]]{1,128}$")
+	clock := guid.NewClock(guid.WithNodeID(0xa))
+	g, l := guid.G(clock), guid.L(clock)
+
+	it.Then(t).Should(
+		it.True(sqs.MatchString(guid.DeduplicationID(g))),
+		it.True(sqs.MatchString(guid.DeduplicationID(l))),
+		it.Equal(guid.DeduplicationID(l), guid.StringLocal(l)),
+		it.Equal(guid.DeduplicationID(guid.Nil()), ""),
+	)
+
+	a, err := guid.FromString(guid.DeduplicationID(g))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(a, g),
+	)
+}
+
+func TestSequenceForKinesis(t *testing.T) {
+	kinesis := regexp.MustCompile(`^(0|[1-9]\d{0,128})$`)
+	clock := guid.NewClock(guid.WithNodeID(0xa))
+	a, b := guid.G(clock), guid.G(clock)
+
+	sa, sb := guid.SequenceForKinesis(a), guid.SequenceForKinesis(b)
+	it.Then(t).Should(
+		it.True(kinesis.MatchString(sa)),
+		it.True(kinesis.MatchString(guid.SequenceForKinesis(guid.L(clock)))),
+		it.Equal(guid.SequenceForKinesis(guid.Nil()), "0"),
+		it.True(len(sa) < len(sb) || (len(sa) == len(sb) && sa < sb)),
+		it.Equal(guid.SequenceForKinesis(guid.WithClass(a, 3)), sa),
+		it.Equal(guid.SequenceForKinesis(guid.TombstoneOf(a)), sa),
+	)
+}
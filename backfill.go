@@ -0,0 +1,96 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+)
+
+// Backfill allocates globally unique k-ordered identifiers with timestamps
+// from historical time range. Identifiers are strictly ordered in the order
+// of allocation. The node location of backfill is derived from the clock
+// by inverting its bits, so that identifiers never collide with live traffic
+// of the clock.
+type Backfill struct {
+	mu       sync.Mutex
+	from, to uint64
+	node     uint64
+	tick     uint64
+	seq      uint64
+	used     bool
+}
+
+// NewBackfill creates allocator of identifiers for time range [from, to)
+func NewBackfill(from, to time.Time, clock Chronos) *Backfill {
+	return &Backfill{
+		from: uint64(from.UnixNano()),
+		to:   uint64(to.UnixNano()),
+		node: ^clock.L() & 0xffffffff,
+	}
+}
+
+// At allocates identifier for historical event. The event timestamp is
+// clamped to the latest allocated one to preserve strict ordering, the
+// sequence ⟨𝒔⟩ is incremented within the tick.
+func (bf *Backfill) At(t time.Time, drift ...time.Duration) (K, error) {
+	at := uint64(t.UnixNano())
+	if t.UnixNano() < 0 || at < bf.from || at >= bf.to {
+		return K{}, fmt.Errorf("timestamp %s is out of backfill range", t)
+	}
+
+	bf.mu.Lock()
+	defer bf.mu.Unlock()
+
+	tick := at >> bitsSeqDrift
+	switch {
+	case !bf.used || tick > bf.tick:
+		bf.tick, bf.seq = tick, 0
+	case bf.seq < 0x3fff:
+		bf.seq++
+	default:
+		bf.tick, bf.seq = bf.tick+1, 0
+	}
+
+	if bf.tick<<bitsSeqDrift >= bf.to {
+		return K{}, fmt.Errorf("backfill range is exhausted at %s", t)
+	}
+
+	bf.used = true
+	return makeG(bf.node, driftInBits(drift), bf.tick<<bitsSeqDrift, bf.seq), nil
+}
+
+// Spread allocates n identifiers, which are evenly spread across the range
+func (bf *Backfill) Spread(n int, drift ...time.Duration) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		if n <= 0 {
+			return
+		}
+
+		step := (bf.to - bf.from) / uint64(n)
+		for i := 0; i < n; i++ {
+			uid, err := bf.At(time.Unix(0, int64(bf.from+uint64(i)*step)), drift...)
+			if err != nil || !yield(uid) {
+				return
+			}
+		}
+	}
+}
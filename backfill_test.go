@@ -0,0 +1,78 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestBackfill(t *testing.T) {
+	from := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := guid.NewClock(guid.WithNodeID(0x0000ffff))
+
+	t.Run("Spread", func(t *testing.T) {
+		bf := guid.NewBackfill(from, to, c)
+		seq := slices.Collect(bf.Spread(1000))
+
+		it.Then(t).Should(
+			it.Equal(len(seq), 1000),
+			it.Nil(guid.CheckOrder(slices.Values(seq), 1)),
+			it.Equal(guid.Node(seq[0]), 0xffff0000),
+			it.True(guid.EpochT(seq[0]).Before(from.Add(time.Millisecond))),
+			it.True(guid.EpochT(seq[999]).After(to.Add(-24*time.Hour))),
+		)
+	})
+
+	t.Run("At", func(t *testing.T) {
+		bf := guid.NewBackfill(from, to, c)
+		a, _ := bf.At(from.Add(time.Hour))
+		b, _ := bf.At(from.Add(time.Hour))
+		d, _ := bf.At(from)
+
+		it.Then(t).Should(
+			it.True(guid.Before(a, b)),
+			it.True(guid.Before(b, d)),
+			it.Equal(guid.Seq(b), 1),
+			it.Equal(guid.Seq(d), 2),
+			it.Equal(guid.Time(a), guid.Time(d)),
+		)
+
+		_, err := bf.At(to)
+		it.Then(t).ShouldNot(it.Nil(err))
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		bf := guid.NewBackfill(from, from.Add(time.Millisecond), c)
+		n := 0
+		for range bf.Spread(1 << 20) {
+			n++
+		}
+
+		it.Then(t).Should(
+			it.True(n > 0x3fff),
+			it.True(n < 1<<20),
+		)
+	})
+}
@@ -20,7 +20,7 @@ package guid
 
 import (
 	"fmt"
-	"math"
+	"math/bits"
 )
 
 var (
@@ -28,50 +28,53 @@ var (
 	decoder = [256]byte{255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 255, 255, 255, 255, 255, 255, 255, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 255, 255, 255, 255, 255, 255, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255}
 )
 
-func encode62(src []byte) []byte {
-	rs := 0
-	cs := int(math.Ceil(math.Log(256) / math.Log(62) * float64(len(src))))
-	dst := make([]byte, cs)
-	for i := range src {
-		c := 0
-		v := int(src[i])
-		for j := cs - 1; j >= 0 && (v != 0 || c < rs); j-- {
-			v += 256 * int(dst[j])
-			dst[j] = byte(v % 62)
-			v /= 62
-			c++
-		}
-		rs = c
-	}
-	for i := range dst {
-		dst[i] = encoder[dst[i]]
+// 62¹⁰ is the largest power of base that fits 64-bit limb
+const base62x10 = 839299365868340224
+
+// encode62 encodes 96-bit number (hi, lo) to base62 digits, leading zeros
+// are omitted. The number is divided by 62¹⁰ so that each limb division
+// yields 10 digits.
+func encode62(hi, lo uint64) []byte {
+	var dst [17]byte
+
+	q1, r := bits.Div64(0, hi, base62x10)
+	q0, r0 := bits.Div64(r, lo, base62x10)
+	_, r1 := bits.Div64(q1, q0, base62x10) // 62¹⁷ > 2⁹⁶, the quotient is 0
+
+	digits62(r1, dst[0:7])
+	digits62(r0, dst[7:17])
+
+	i := 0
+	for i < len(dst) && dst[i] == '0' {
+		i++
 	}
-	if cs > rs {
-		return dst[cs-rs:]
+
+	return dst[i:]
+}
+
+func digits62(x uint64, dst []byte) {
+	for i := len(dst) - 1; i >= 0; i-- {
+		dst[i] = encoder[x%62]
+		x /= 62
 	}
-	return dst
 }
 
-func decode62(src []byte) ([]byte, error) {
-	rs := 0
-	cs := int(math.Ceil(math.Log(62) / math.Log(256) * float64(len(src))))
-	dst := make([]byte, cs)
-	for i := range src {
-		c := 0
-		v := int(decoder[src[i]])
+// decode62 decodes base62 digits to 96-bit number (hi, lo)
+func decode62(src []byte) (hi, lo uint64, err error) {
+	for _, x := range src {
+		v := decoder[x]
 		if v == 255 {
-			return nil, fmt.Errorf("corrupted input: %v", src[i])
+			return 0, 0, fmt.Errorf("corrupted input: %v", x)
 		}
-		for j := cs - 1; j >= 0 && (v != 0 || c < rs); j-- {
-			v += 62 * int(dst[j])
-			dst[j] = byte(v % 256)
-			v /= 256
-			c++
+
+		var c, carry uint64
+		c, lo = bits.Mul64(lo, 62)
+		lo, carry = bits.Add64(lo, uint64(v), 0)
+		hi = hi*62 + c + carry
+		if hi > 0xffffffff {
+			return 0, 0, fmt.Errorf("malformed k-order number: %s", src)
 		}
-		rs = c
-	}
-	if cs > rs {
-		return dst[cs-rs:], nil
 	}
-	return dst, nil
+
+	return hi, lo, nil
 }
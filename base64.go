@@ -18,12 +18,16 @@
 
 package guid
 
-var alphabet []byte = []byte(".0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz")
+const alphabet = ".0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
 
-func encode64(in [16]byte, out *[16]byte) {
-	for i := 0; i < len(in); i++ {
-		out[i] = alphabet[in[i]]
+// valid64 checks that string consists of sortable alphabet chars only
+func valid64(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if decoder64(s[i]) > 0x3f {
+			return false
+		}
 	}
+	return true
 }
 
 func decode64(uid string) []byte {
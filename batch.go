@@ -0,0 +1,94 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+// ToLAll casts batch of global (96-bit) k-order values to local (64-bit) ones
+func ToLAll(ids []K) []K {
+	return AppendToL(make([]K, 0, len(ids)), ids)
+}
+
+// AppendToL appends local (64-bit) casts of ids to dst. The conversion is
+// in-place when dst is ids[:0].
+func AppendToL(dst []K, ids []K) []K {
+	for _, uid := range ids {
+		dst = append(dst, ToL(uid))
+	}
+	return dst
+}
+
+// FromLAll casts batch of local (64-bit) k-order values to global (96-bit)
+// ones using ⟨𝒍⟩ location of the clock.
+func FromLAll(clock Chronos, ids []K) []K {
+	return AppendFromL(make([]K, 0, len(ids)), clock, ids)
+}
+
+// AppendFromL appends global (96-bit) casts of ids to dst. The conversion is
+// in-place when dst is ids[:0].
+func AppendFromL(dst []K, clock Chronos, ids []K) []K {
+	node := clock.L()
+	for _, uid := range ids {
+		if uid.Hi != 0 {
+			dst = append(dst, uid)
+			continue
+		}
+
+		d := (uid.Lo >> 61) + driftZ
+		dst = append(dst, makeG(node, d, Time(uid), Seq(uid)))
+	}
+	return dst
+}
+
+// Times extracts ⟨𝒕⟩ fractions of batch (see Time)
+func Times(ids []K) []uint64 {
+	return AppendTimes(make([]uint64, 0, len(ids)), ids)
+}
+
+// AppendTimes appends ⟨𝒕⟩ fractions of ids to dst
+func AppendTimes(dst []uint64, ids []K) []uint64 {
+	for _, uid := range ids {
+		dst = append(dst, Time(uid))
+	}
+	return dst
+}
+
+// Nodes extracts ⟨𝒍⟩ fractions of batch (see Node)
+func Nodes(ids []K) []uint32 {
+	return AppendNodes(make([]uint32, 0, len(ids)), ids)
+}
+
+// AppendNodes appends ⟨𝒍⟩ fractions of ids to dst
+func AppendNodes(dst []uint32, ids []K) []uint32 {
+	for _, uid := range ids {
+		dst = append(dst, uint32(Node(uid)))
+	}
+	return dst
+}
+
+// Seqs extracts ⟨𝒔⟩ fractions of batch (see Seq)
+func Seqs(ids []K) []uint16 {
+	return AppendSeqs(make([]uint16, 0, len(ids)), ids)
+}
+
+// AppendSeqs appends ⟨𝒔⟩ fractions of ids to dst
+func AppendSeqs(dst []uint16, ids []K) []uint16 {
+	for _, uid := range ids {
+		dst = append(dst, uint16(uid.Lo&0x3fff))
+	}
+	return dst
+}
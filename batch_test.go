@@ -0,0 +1,102 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestBatchCast(t *testing.T) {
+	c := guid.NewClock(guid.WithNodeID(0xa))
+	g := []guid.K{guid.G(c), guid.G(c), guid.G(c)}
+	l := []guid.K{guid.ToL(g[0]), guid.ToL(g[1]), guid.ToL(g[2])}
+
+	t.Run("ToLAll", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Seq(guid.ToLAll(g)).Equal(l...),
+			it.Seq(guid.FromLAll(c, l)).Equal(g...),
+		)
+	})
+
+	t.Run("InPlace", func(t *testing.T) {
+		ids := append([]guid.K{}, g...)
+		out := guid.AppendToL(ids[:0], ids)
+
+		it.Then(t).Should(
+			it.Seq(ids).Equal(l...),
+			it.Equal(&out[0], &ids[0]),
+		)
+
+		guid.AppendFromL(ids[:0], c, ids)
+		it.Then(t).Should(
+			it.Seq(ids).Equal(g...),
+		)
+	})
+}
+
+func TestBatchFractions(t *testing.T) {
+	c := guid.NewClock(guid.WithNodeID(0xa))
+	ids := []guid.K{guid.G(c), guid.L(c), guid.G(c, time.Hour)}
+
+	times := guid.Times(ids)
+	nodes := guid.Nodes(ids)
+	seqs := guid.Seqs(ids)
+	for i, uid := range ids {
+		it.Then(t).Should(
+			it.Equal(times[i], guid.Time(uid)),
+			it.Equal(uint64(nodes[i]), guid.Node(uid)),
+			it.Equal(uint64(seqs[i]), guid.Seq(uid)),
+		)
+	}
+
+	buf := make([]uint64, 0, 8)
+	out := guid.AppendTimes(buf, ids)
+	it.Then(t).Should(
+		it.Equal(&out[0], &buf[:1][0]),
+		it.Seq(out).Equal(times...),
+	)
+}
+
+func BenchmarkBatchFractions(b *testing.B) {
+	ids := make([]guid.K, 1024)
+	for i := range ids {
+		ids[i] = guid.G(guid.Clock)
+	}
+
+	times := make([]uint64, 0, len(ids))
+	nodes := make([]uint32, 0, len(ids))
+
+	b.Run("Times", func(b *testing.B) {
+		b.SetBytes(12 * 1024)
+		for i := 0; i < b.N; i++ {
+			times = guid.AppendTimes(times[:0], ids)
+		}
+	})
+
+	b.Run("Nodes", func(b *testing.B) {
+		b.SetBytes(12 * 1024)
+		for i := 0; i < b.N; i++ {
+			nodes = guid.AppendNodes(nodes[:0], ids)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Binding maps string identifier of transport object (DTO) to k-ordered value
+// of domain object. Declaring bindings once serves both directions:
+//
+//	func bindings(dto *OrderDTO, order *Order) []guid.Binding {
+//		return []guid.Binding{
+//			{Name: "id", Text: &dto.ID, K: &order.ID},
+//			{Name: "parent", Text: &dto.Parent, K: &order.Parent},
+//		}
+//	}
+//
+//	err := guid.Bind(bindings(&dto, &order)...)
+//	guid.Unbind(bindings(&dto, &order)...)
+type Binding struct {
+	Name string
+	Text *string
+	K    *K
+}
+
+// BindError reports the binding of malformed identifier
+type BindError struct {
+	Name string
+	Err  error
+}
+
+func (e *BindError) Error() string {
+	return fmt.Sprintf("field %s: %v", e.Name, e.Err)
+}
+
+func (e *BindError) Unwrap() error { return e.Err }
+
+// Bind decodes string identifiers into k-ordered values (see FromString).
+// All bindings are decoded, errors are joined as *BindError.
+func Bind(bindings ...Binding) error {
+	var errs []error
+	for _, b := range bindings {
+		uid, err := FromString(*b.Text)
+		if err != nil {
+			errs = append(errs, &BindError{Name: b.Name, Err: err})
+			continue
+		}
+		*b.K = uid
+	}
+	return errors.Join(errs...)
+}
+
+// Unbind encodes k-ordered values into string identifiers, it is inverse
+// to Bind.
+func Unbind(bindings ...Binding) {
+	for _, b := range bindings {
+		*b.Text = formatString(*b.K)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+type orderDTO struct{ ID, Parent string }
+
+type order struct{ ID, Parent guid.K }
+
+func bindings(dto *orderDTO, obj *order) []guid.Binding {
+	return []guid.Binding{
+		{Name: "id", Text: &dto.ID, K: &obj.ID},
+		{Name: "parent", Text: &dto.Parent, K: &obj.Parent},
+	}
+}
+
+func TestBind(t *testing.T) {
+	obj := order{ID: guid.G(guid.Clock)}
+
+	var dto orderDTO
+	guid.Unbind(bindings(&dto, &obj)...)
+	it.Then(t).Should(
+		it.Equal(dto.ID, obj.ID.String()),
+		it.Equal(dto.Parent, ""),
+	)
+
+	var x order
+	err := guid.Bind(bindings(&dto, &x)...)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x, obj),
+	)
+}
+
+func TestBindError(t *testing.T) {
+	dto := orderDTO{ID: "!", Parent: "?"}
+
+	var x order
+	err := guid.Bind(bindings(&dto, &x)...)
+
+	var e *guid.BindError
+	it.Then(t).Should(
+		it.True(errors.As(err, &e)),
+		it.Equal(e.Name, "id"),
+		it.String(err.Error()).Contain("parent"),
+	)
+}
@@ -0,0 +1,69 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNonCanonical is returned by Strict for non-canonical k-ordered values
+var ErrNonCanonical = errors.New("guid: non-canonical k-order number")
+
+// Canonical checks that k-ordered value is produced by the library: unused
+// bits above 96 are zero (the in-memory priority class is rejected, see
+// WithClass, delete-marker is permitted, see TombstoneOf) and ⟨𝒅⟩ drift bits
+// are allocated. Nil is canonical. The check catches corruption
+// and misuse of raw Hi/Lo construction.
+func Canonical(uid K) bool {
+	switch {
+	case uid == Nil():
+		return true
+	case uid.Hi&^tombstoneBit > 0xffffffff:
+		return false
+	default:
+		return driftOf(uid) != driftZ
+	}
+}
+
+// Strict rejects non-canonical values returned by decoder, the error of
+// decoder is passed as is:
+//
+//	uid, err := guid.Strict(guid.FromBase62(s))
+func Strict(uid K, err error) (K, error) {
+	if err != nil {
+		return K{}, err
+	}
+
+	if !Canonical(uid) {
+		return K{}, fmt.Errorf("%w: %v", ErrNonCanonical, uid)
+	}
+
+	return uid, nil
+}
+
+// decodeStrict decodes lexicographically sortable string of canonical value,
+// empty strings and characters outside of the alphabet are rejected
+func decodeStrict(val string) (K, error) {
+	if !isAlphabet64(val) {
+		return K{}, fmt.Errorf("malformed k-order number: %v", val)
+	}
+
+	return Strict(fromStringNonEmpty(val))
+}
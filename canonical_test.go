@@ -0,0 +1,67 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestCanonical(t *testing.T) {
+	g := guid.G(guid.Clock)
+
+	it.Then(t).Should(
+		it.True(guid.Canonical(g)),
+		it.True(guid.Canonical(guid.ToL(g))),
+		it.True(guid.Canonical(guid.TombstoneOf(g))),
+		it.True(!guid.Canonical(guid.WithClass(g, 3))),
+		it.True(!guid.Canonical(guid.WithClass(g, 1))),
+		it.True(guid.Canonical(guid.Nil())),
+		it.True(guid.Canonical(guid.Tombstone())),
+		it.True(!guid.Canonical(guid.K{Hi: g.Hi | 1<<40, Lo: g.Lo})),
+		it.True(!guid.Canonical(guid.K{Hi: 1, Lo: g.Lo})),
+		it.True(!guid.Canonical(guid.K{Lo: 1})),
+	)
+}
+
+func TestStrict(t *testing.T) {
+	g := guid.G(guid.Clock)
+
+	x, err := guid.Strict(guid.FromString(guid.String(g)))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x, g),
+	)
+
+	_, err = guid.Strict(guid.FromBase62(guid.Base62(guid.K{Hi: 1 << 28, Lo: 1})))
+	it.Then(t).Should(
+		it.True(errors.Is(err, guid.ErrNonCanonical)),
+	)
+
+	for _, garbage := range []string{"!", "!!!!!!!!!!!!!!!!", "*!!!!!!!!!!!!!!!!", "NljBVm51PwMrZR-1"} {
+		_, err = guid.Strict(guid.FromString(garbage))
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+			it.True(errors.Is(err, guid.ErrNonCanonical)),
+		)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Cardinality is HyperLogLog sketch estimating number of distinct k-ordered
+// values. Values are hashed directly from their bits, without stringifying.
+// The hash covers ⟨𝒕⟩ as well as ⟨𝒍⟩ and ⟨𝒔⟩ because ⟨𝒔⟩ sequence wraps.
+type Cardinality struct {
+	p         uint8
+	registers []uint8
+}
+
+// NewCardinality creates sketch with 2ᵖ registers (4 ≤ p ≤ 16), the standard
+// error of estimate is about 1.04/√2ᵖ.
+func NewCardinality(p int) *Cardinality {
+	if p < 4 || p > 16 {
+		panic("guid: cardinality precision must be within 4 and 16")
+	}
+
+	return &Cardinality{p: uint8(p), registers: make([]uint8, 1<<p)}
+}
+
+// Add identifiers to the sketch
+func (c *Cardinality) Add(ids ...K) {
+	for _, uid := range ids {
+		h := mix64(mix64(uid.Hi) ^ uid.Lo)
+		i := h >> (64 - c.p)
+		w := h<<c.p | 1<<(c.p-1)
+		rho := uint8(bits.LeadingZeros64(w) + 1)
+		if rho > c.registers[i] {
+			c.registers[i] = rho
+		}
+	}
+}
+
+// Merge other sketch of same precision
+func (c *Cardinality) Merge(other *Cardinality) {
+	if c.p != other.p {
+		panic("guid: cardinality sketches of different precision")
+	}
+
+	for i, r := range other.registers {
+		if r > c.registers[i] {
+			c.registers[i] = r
+		}
+	}
+}
+
+// Estimate number of distinct identifiers
+func (c *Cardinality) Estimate() uint64 {
+	m := float64(len(c.registers))
+
+	sum, zeros := 0.0, 0
+	for _, r := range c.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	var alpha float64
+	switch len(c.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+
+	e := alpha * m * m / sum
+	if e <= 2.5*m && zeros > 0 {
+		// linear counting for small cardinalities
+		e = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(e + 0.5)
+}
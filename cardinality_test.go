@@ -0,0 +1,56 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestCardinality(t *testing.T) {
+	c := guid.NewClock()
+	a := guid.NewCardinality(14)
+	b := guid.NewCardinality(14)
+
+	for i := 0; i < 100000; i++ {
+		uid := guid.G(c)
+		a.Add(uid, uid)
+		if i%2 == 0 {
+			b.Add(uid)
+		}
+	}
+
+	within := func(x uint64, n float64) bool {
+		return math.Abs(float64(x)-n)/n < 0.03
+	}
+
+	it.Then(t).Should(
+		it.True(within(a.Estimate(), 100000)),
+		it.True(within(b.Estimate(), 50000)),
+	)
+
+	b.Merge(a)
+	it.Then(t).Should(
+		it.True(within(b.Estimate(), 100000)),
+		it.Equal(guid.NewCardinality(14).Estimate(), 0),
+	)
+}
@@ -0,0 +1,88 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package cbork implements CBOR codec of k-ordered values and events.
+// K is encoded as CBOR byte string of binary form (see guid.Bytes).
+package cbork
+
+import (
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// K is k-ordered value, which implements cbor.Marshaler and cbor.Unmarshaler
+type K guid.K
+
+// MarshalCBOR encodes k-ordered value as byte string
+func (uid K) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(guid.Bytes(guid.K(uid)))
+}
+
+// UnmarshalCBOR decodes k-ordered value from byte string
+func (uid *K) UnmarshalCBOR(b []byte) error {
+	var val []byte
+	if err := cbor.Unmarshal(b, &val); err != nil {
+		return err
+	}
+
+	k, err := guid.FromBytes(val)
+	if err != nil {
+		return err
+	}
+
+	*uid = K(k)
+	return nil
+}
+
+// timestamps are encoded with nanosecond precision
+var encMode, _ = cbor.EncOptions{Time: cbor.TimeRFC3339Nano}.EncMode()
+
+// wire format of event
+type event[T any] struct {
+	ID   K         `cbor:"1,keyasint"`
+	At   time.Time `cbor:"2,keyasint"`
+	Node uint32    `cbor:"3,keyasint"`
+	Data T         `cbor:"4,keyasint"`
+}
+
+// MarshalEvent encodes event to CBOR
+func MarshalEvent[T any](evt guid.Event[T]) ([]byte, error) {
+	return encMode.Marshal(event[T]{
+		ID:   K(evt.ID),
+		At:   evt.At,
+		Node: evt.Node,
+		Data: evt.Data,
+	})
+}
+
+// UnmarshalEvent decodes event from CBOR
+func UnmarshalEvent[T any](b []byte) (guid.Event[T], error) {
+	var evt event[T]
+	if err := cbor.Unmarshal(b, &evt); err != nil {
+		return guid.Event[T]{}, err
+	}
+
+	return guid.Event[T]{
+		ID:   guid.K(evt.ID),
+		At:   evt.At,
+		Node: evt.Node,
+		Data: evt.Data,
+	}, nil
+}
@@ -0,0 +1,61 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package cbork_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/cbork"
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestK(t *testing.T) {
+	for _, uid := range []guid.K{guid.G(guid.Clock), guid.L(guid.Clock)} {
+		b, err := cbor.Marshal(cbork.K(uid))
+		it.Then(t).Should(it.Nil(err))
+
+		var x cbork.K
+		err = cbor.Unmarshal(b, &x)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(guid.K(x), uid),
+		)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	type Note struct {
+		Text string `cbor:"text"`
+	}
+
+	evt := guid.NewEvent(guid.Clock, Note{Text: "hello"})
+	b, err := cbork.MarshalEvent(evt)
+	it.Then(t).Should(it.Nil(err))
+
+	x, err := cbork.UnmarshalEvent[Note](b)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x.ID, evt.ID),
+		it.True(x.At.Equal(evt.At)),
+		it.Equal(x.Node, evt.Node),
+		it.Equal(x.Data, evt.Data),
+	)
+}
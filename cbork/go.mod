@@ -0,0 +1,13 @@
+module github.com/fogfish/guid/cbork
+
+go 1.23
+
+replace github.com/fogfish/guid/v2 => ../
+
+require (
+	github.com/fogfish/guid/v2 v2.0.0
+	github.com/fogfish/it/v2 v2.0.1
+	github.com/fxamacker/cbor/v2 v2.9.4
+)
+
+require github.com/x448/float16 v0.8.4 // indirect
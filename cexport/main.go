@@ -0,0 +1,113 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Command cexport builds C shared library of k-ordered identifiers, so
+// that non-Go services on the same hosts mint compatible identifiers.
+//
+//	go build -buildmode=c-shared -o libguid.so ./cexport
+//
+// The library exports functions, which write NUL-terminated output into the
+// buffer provided by caller. Functions return the length of output or -1 if
+// input is malformed or buffer is too small.
+//
+//	int guid_new(int local, char *out, int size);
+//	int guid_parse(char *in, unsigned char *out, int size);
+//	int guid_inspect(char *in, char *out, int size);
+//
+// The node location of identifiers is configured by CONFIG_GUID_NODE_ID
+// environment variable, it is random otherwise.
+package main
+
+import "C"
+
+import (
+	"os"
+	"unsafe"
+
+	"github.com/fogfish/guid/v2"
+)
+
+var clock = newClock()
+
+func newClock() guid.Chronos {
+	if _, has := os.LookupEnv("CONFIG_GUID_NODE_ID"); has {
+		return guid.NewClock(guid.WithNodeFromEnv())
+	}
+	return guid.NewClock()
+}
+
+// guid_new writes string of new global identifier, or local one if local
+// is non-zero (see guid.StringLocal).
+//
+//export guid_new
+func guid_new(local C.int, out *C.char, size C.int) C.int {
+	if local != 0 {
+		return output(out, size, []byte(guid.StringLocal(guid.L(clock))))
+	}
+	return output(out, size, []byte(guid.String(guid.G(clock))))
+}
+
+// guid_parse decodes string of identifier (see guid.FromString) into its
+// binary form (see guid.Bytes), the output is not NUL-terminated.
+//
+//export guid_parse
+func guid_parse(in *C.char, out *C.uchar, size C.int) C.int {
+	uid, err := guid.FromString(C.GoString(in))
+	if err != nil {
+		return -1
+	}
+
+	b := guid.Bytes(uid)
+	if int(size) < len(b) {
+		return -1
+	}
+
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(out)), len(b)), b)
+	return C.int(len(b))
+}
+
+// guid_inspect writes JSON breakdown of identifier (see guid.Dissect)
+//
+//export guid_inspect
+func guid_inspect(in *C.char, out *C.char, size C.int) C.int {
+	uid, err := guid.FromString(C.GoString(in))
+	if err != nil {
+		return -1
+	}
+
+	b, err := guid.DissectorJSON(guid.Bytes(uid))
+	if err != nil {
+		return -1
+	}
+
+	return output(out, size, b)
+}
+
+// writes NUL-terminated string into buffer of caller
+func output(out *C.char, size C.int, b []byte) C.int {
+	if int(size) < len(b)+1 {
+		return -1
+	}
+
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(out)), len(b)+1)
+	copy(buf, b)
+	buf[len(b)] = 0
+	return C.int(len(b))
+}
+
+func main() {}
@@ -0,0 +1,148 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// Checkpoint is a high-water mark of k-ordered values per node location ⟨𝒍⟩.
+// It represents the state "everything before these identifiers is processed"
+// of resumable consumers of k-ordered streams.
+type Checkpoint map[uint64]K
+
+// Add advances high-water marks with identifiers
+func (cp Checkpoint) Add(uids ...K) {
+	for _, uid := range uids {
+		node := Node(uid)
+		if mark, has := cp[node]; !has || After(uid, mark) {
+			cp[node] = uid
+		}
+	}
+}
+
+// Merge advances high-water marks with marks of other checkpoint
+func (cp Checkpoint) Merge(other Checkpoint) {
+	for _, mark := range other {
+		cp.Add(mark)
+	}
+}
+
+// Covers checks if identifier is behind the high-water mark of its node
+func (cp Checkpoint) Covers(uid K) bool {
+	mark, has := cp[Node(uid)]
+	return has && !After(uid, mark)
+}
+
+// LowWatermark returns the earliest of identifiers
+func LowWatermark(ids ...K) (mark K) {
+	for i, uid := range ids {
+		if i == 0 || Before(uid, mark) {
+			mark = uid
+		}
+	}
+	return
+}
+
+// HighWatermark returns the latest of identifiers
+func HighWatermark(ids ...K) (mark K) {
+	for i, uid := range ids {
+		if i == 0 || After(uid, mark) {
+			mark = uid
+		}
+	}
+	return
+}
+
+// SafeWatermark shifts watermark backward by the drift window of identifier.
+// Identifiers allocated by other nodes, which are skewed within the drift,
+// are after safe watermark. It gives safe reprocessing cut-off for
+// stream processors.
+func SafeWatermark(k K) K {
+	d := driftOf(k)
+	w := uint64(driftStepOf(d))
+
+	t := Time(k)
+	if t < w {
+		t = 0
+	} else {
+		t -= w
+	}
+
+	if k.Hi == 0 {
+		return makeL(d, t, 0)
+	}
+	return makeG(0, d, t, 0)
+}
+
+// MarshalBinary encodes checkpoint as sequence of high-water marks ordered
+// by node location. Each mark is prefixed by its length.
+func (cp Checkpoint) MarshalBinary() ([]byte, error) {
+	nodes := make([]uint64, 0, len(cp))
+	for node := range cp {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+
+	b := binary.AppendUvarint(make([]byte, 0, 4+len(cp)*(1+bytesInG)), uint64(len(cp)))
+	for _, node := range nodes {
+		mark := Bytes(cp[node])
+		b = append(b, byte(len(mark)))
+		b = append(b, mark...)
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary decodes checkpoint
+func (cp *Checkpoint) UnmarshalBinary(b []byte) error {
+	n, size := binary.Uvarint(b)
+	if size <= 0 {
+		return fmt.Errorf("malformed checkpoint: %v", b)
+	}
+	b = b[size:]
+
+	// each mark is at least length prefix and local value
+	if n > uint64(len(b)/(1+bytesInL)) {
+		return fmt.Errorf("malformed checkpoint: %d marks exceed %d bytes", n, len(b))
+	}
+
+	seq := make(Checkpoint, n)
+	for i := uint64(0); i < n; i++ {
+		if len(b) == 0 || len(b) < 1+int(b[0]) {
+			return fmt.Errorf("malformed checkpoint: truncated")
+		}
+
+		mark, err := FromBytes(b[1 : 1+b[0]])
+		if err != nil {
+			return err
+		}
+		seq.Add(mark)
+		b = b[1+b[0]:]
+	}
+
+	if len(b) != 0 {
+		return fmt.Errorf("malformed checkpoint: %d trailing bytes", len(b))
+	}
+
+	*cp = seq
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestCheckpoint(t *testing.T) {
+	ca := guid.NewClock(guid.WithNodeID(0xa))
+	cb := guid.NewClock(guid.WithNodeID(0xb))
+
+	a0, a1, a2 := guid.G(ca), guid.G(ca), guid.G(ca)
+	b0, b1 := guid.G(cb), guid.G(cb)
+
+	cp := guid.Checkpoint{}
+	cp.Add(a1, a0, b0)
+
+	it.Then(t).Should(
+		it.Equal(len(cp), 2),
+		it.Equal(cp[0xa], a1),
+		it.Equal(cp[0xb], b0),
+		it.True(cp.Covers(a0)),
+		it.True(cp.Covers(a1)),
+		it.True(cp.Covers(b0)),
+	).ShouldNot(
+		it.True(cp.Covers(a2)),
+		it.True(cp.Covers(b1)),
+		it.True(cp.Covers(guid.G(guid.NewClock()))),
+	)
+
+	t.Run("Merge", func(t *testing.T) {
+		other := guid.Checkpoint{}
+		other.Add(a0, b1)
+		other.Merge(cp)
+
+		it.Then(t).Should(
+			it.Equal(other[0xa], a1),
+			it.Equal(other[0xb], b1),
+		)
+	})
+
+	t.Run("Codec", func(t *testing.T) {
+		cp.Add(guid.L(ca))
+		b, err := cp.MarshalBinary()
+		it.Then(t).Should(it.Nil(err))
+
+		var x guid.Checkpoint
+		err = x.UnmarshalBinary(b)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(x, cp),
+		)
+
+		it.Then(t).ShouldNot(
+			it.Nil(x.UnmarshalBinary(nil)),
+			it.Nil(x.UnmarshalBinary(b[:len(b)-1])),
+			it.Nil(x.UnmarshalBinary(append(b, 0))),
+			it.Nil(x.UnmarshalBinary([]byte{0xff, 0xff, 0xff, 0x7f})),
+			it.Nil(x.UnmarshalBinary([]byte{0xff, 0xff, 0xff, 0xff, 0x0f})),
+			it.Nil(x.UnmarshalBinary(binary.AppendUvarint(nil, 1<<62))),
+		)
+	})
+}
+
+func TestWatermark(t *testing.T) {
+	c := guid.NewClock()
+	a, b, d := guid.G(c), guid.G(c), guid.G(c)
+
+	it.Then(t).Should(
+		it.Equal(guid.LowWatermark(b, d, a), a),
+		it.Equal(guid.HighWatermark(b, d, a), d),
+		it.Equal(guid.LowWatermark(), guid.K{}),
+		it.Equal(guid.HighWatermark(), guid.K{}),
+	)
+
+	for _, drift := range drifts {
+		at := time.Now()
+		ca := guid.NewClock(
+			guid.WithNodeID(0xffffffff),
+			guid.WithClock(func() uint64 { return uint64(at.UnixNano()) }),
+		)
+		cb := guid.NewClock(
+			guid.WithNodeID(0x0),
+			guid.WithClock(func() uint64 { return uint64(at.Add(-drift).UnixNano()) }),
+		)
+
+		for _, f := range []func(guid.Chronos, ...time.Duration) guid.K{guid.G, guid.L} {
+			mark := guid.SafeWatermark(f(ca, drift))
+			it.Then(t).Should(
+				it.True(guid.Before(mark, f(cb, drift))),
+				it.True(guid.Time(mark) <= uint64(at.Add(-drift).UnixNano())),
+			)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"cmp"
+	"time"
+)
+
+// class occupies 2 high bits of Hi, which are not used by 96-bit identifiers
+const (
+	classShift = 62
+	classMask  = uint64(3) << classShift
+)
+
+// GWithClass generates globally unique 96-bit k-ordered identifier, same as G,
+// tagged with 2-bit priority class (0 ≤ class ≤ 3). Classes segregate traffic
+// within single in-memory key space (e.g. priority queues). The class is part
+// of in-memory value only, binary and text encodings drop it and values with
+// class are not canonical (see Canonical). Persisted key spaces keep the class
+// apart from the identifier (e.g. key prefix), use WithClass to restore it
+// after decoding.
+func GWithClass(clock Chronos, class uint8, drift ...time.Duration) K {
+	return WithClass(G(clock, drift...), class)
+}
+
+// WithClass tags global k-ordered value with priority class, the class is
+// part of in-memory value only (see GWithClass)
+func WithClass(uid K, class uint8) K {
+	if class > 3 {
+		panic("guid: class must be within 0 and 3")
+	}
+
+	if uid.Hi == 0 {
+		panic("guid: class requires global identifier")
+	}
+
+	uid.Hi = uid.Hi&^classMask | uint64(class)<<classShift
+	return uid
+}
+
+// Class returns priority class of k-ordered value
+func Class(uid K) uint8 {
+	return uint8(uid.Hi >> classShift)
+}
+
+// CompareClassMajor orders k-ordered values by class first, then in k-order.
+// It is the raw order of values, same as Before.
+func CompareClassMajor(a, b K) int {
+	switch {
+	case Before(a, b):
+		return -1
+	case Before(b, a):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CompareTimeMajor orders k-ordered values in k-order first, class breaks ties,
+// delete-marker follows the original value (see TombstoneOf).
+func CompareTimeMajor(a, b K) int {
+	const mask = classMask | tombstoneBit
+
+	if c := cmp.Compare(a.Hi&^mask, b.Hi&^mask); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(Class(a), Class(b)); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.Hi&tombstoneBit, b.Hi&tombstoneBit)
+}
@@ -0,0 +1,50 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestGWithClass(t *testing.T) {
+	c := guid.NewClock(guid.WithNodeID(0xa))
+
+	a := guid.GWithClass(c, 3)
+	b := guid.GWithClass(c, 1)
+	x := guid.WithClass(b, 0)
+
+	it.Then(t).Should(
+		it.Equal(guid.Class(a), 3),
+		it.Equal(guid.Class(b), 1),
+		it.Equal(guid.Class(x), 0),
+		// fractions are not affected by class
+		it.Equal(guid.Node(a), 0xa),
+		it.Equal(guid.Time(b), guid.Time(x)),
+		it.Equal(guid.Seq(b), guid.Seq(x)),
+		it.Equal(guid.String(b), guid.String(x)),
+		it.True(guid.SameEvent(b, x)),
+		// ordering rules
+		it.Equal(guid.CompareClassMajor(a, b), 1),
+		it.Equal(guid.CompareTimeMajor(a, b), -1),
+		it.Equal(guid.CompareTimeMajor(b, x), 1),
+	)
+}
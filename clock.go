@@ -19,8 +19,12 @@ package guid
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"errors"
 	"io"
 	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -32,12 +36,106 @@ type Chronos interface {
 	T() (uint64, uint64)
 }
 
+// Observer is a logical clock that returns ⟨𝒕⟩, ⟨𝒍⟩ and ⟨𝒔⟩ fractions from
+// one consistent observation. Clocks that change node location dynamically
+// (e.g. leased node ids) implement it to prevent torn reads of T and L.
+type Observer interface {
+	Chronos
+	Now() (t, l, s uint64)
+}
+
+// LogicalClock is the logical clock created by the library (see NewClock),
+// which derives variants of itself sharing its configuration.
+type LogicalClock interface {
+	Chronos
+	// Clone derives a new logical clock applying config options on top of
+	// the configuration, the origin clock is not modified.
+	Clone(opts ...Config) LogicalClock
+	// Lease reserves the block of n sequence numbers (see SequenceLease)
+	Lease(n int) *SequenceLease
+	// Sequence derives logical clock with independent ⟨𝒔⟩ sequence of the stream
+	Sequence(name string) LogicalClock
+}
+
+// AsObserver adapts logical clock to Observer. The clock implementing
+// Observer is returned as is, otherwise fractions are read sequentially.
+func AsObserver(clock Chronos) Observer {
+	if o, ok := clock.(Observer); ok {
+		return o
+	}
+	return observer{clock}
+}
+
+type observer struct{ Chronos }
+
+func (o observer) Now() (t, l, s uint64) {
+	t, s = o.T()
+	return t, o.L(), s
+}
+
+// observe all fractions of the clock
+func observe(clock Chronos) (t, l, s uint64) {
+	if o, ok := clock.(Observer); ok {
+		return o.Now()
+	}
+
+	t, s = clock.T()
+	return t, clock.L(), s
+}
+
 // Clock is global default instance of logical clock
 //
 // If the application needs own default clock e.g. inverse one, it declares own
 // clock and pair of GID & LID functions.
+//
+// Deprecated: the variable cannot be replaced safely while application is
+// running. Use Default and SetDefaultClock. The library observes the variable
+// until SetDefaultClock is called.
 var Clock Chronos = NewClock()
 
+// process-wide default clock, initially refers to Clock variable
+var defaultClock atomic.Pointer[Chronos]
+
+func init() {
+	defaultClock.Store(&Clock)
+}
+
+// Default returns process-wide default instance of logical clock
+func Default() Chronos {
+	return *defaultClock.Load()
+}
+
+// SetDefaultClock atomically replaces process-wide default instance of
+// logical clock. Application configures the clock once at startup.
+func SetDefaultClock(clock Chronos) {
+	defaultClock.Store(&clock)
+}
+
+// registry of named clocks
+var namedClocks = struct {
+	sync.RWMutex
+	clocks map[string]Chronos
+}{clocks: map[string]Chronos{}}
+
+// RegisterClock associates the clock with a name, so that application maintains
+// separate clock domains (e.g. "billing", "audit") and references them by name.
+// The registration replaces the clock previously associated with the name.
+func RegisterClock(name string, clock Chronos) {
+	namedClocks.Lock()
+	defer namedClocks.Unlock()
+
+	namedClocks.clocks[name] = clock
+}
+
+// ClockByName looks up the clock associated with the name
+func ClockByName(name string) (Chronos, bool) {
+	namedClocks.RLock()
+	defer namedClocks.RUnlock()
+
+	clock, has := namedClocks.clocks[name]
+	return clock, has
+}
+
 // Logical Clock Type, the default one
 type clock struct {
 	// Spatially unique identifier ⟨𝒍⟩
@@ -45,45 +143,98 @@ type clock struct {
 	// Monotonically increasing logical clock ⟨𝒕⟩ generator
 	ticker func() uint64
 	unique func() uint64
+	// Shared counter behind unique generator, nil if generator is custom
+	counter *int64
+	step    int64
+	// External time source with bounded uncertainty, and drift bits
+	// covering its uncertainty observed so far
+	source TimeSource
+	drift  *atomic.Uint64
+	// Reserved ranges of ⟨𝒍⟩, random allocation avoids them
+	reserved [][2]uint64
+	random   bool
+	// Journal of allocated identifiers, shared by clones
+	journal *journal
+	// Named sequences derived from the clock
+	sequences *sequences
 }
 
 func (clock clock) L() uint64           { return clock.location }
 func (clock clock) T() (uint64, uint64) { return clock.ticker(), clock.unique() }
+func (clock clock) Now() (t, l, s uint64) {
+	return clock.ticker(), clock.location, clock.unique()
+}
 
-// Creates instance of logical clock
-func NewClock(opts ...Config) Chronos {
-	clock := &clock{}
+// Creates instance of logical clock.
+// Config options are applied only while clock is constructed, the clock is
+// immutable afterwards and safe for concurrent use. Use Clone to derive variants.
+func NewClock(opts ...Config) LogicalClock {
 	defopt := []Config{WithClockUnix(), WithNodeRandom()}
 
-	for _, opt := range append(defopt, opts...) {
-		opt(clock)
-	}
-	return clock
+	return clock{}.with(append(defopt, opts...))
 }
 
 // Create mock instance of logical clock
-func NewClockMock(opts ...Config) Chronos {
-	clock := &clock{
+func NewClockMock(opts ...Config) LogicalClock {
+	clock := clock{
 		location: 0,
 		ticker:   func() uint64 { return 0 },
 		unique:   func() uint64 { return 0 },
 	}
 
+	return clock.with(opts)
+}
+
+// Clone derives a new logical clock from existing one, applying config options
+// on top of its configuration. The origin clock is not modified.
+func (clock clock) Clone(opts ...Config) LogicalClock {
+	return clock.with(opts)
+}
+
+// applies config options to the copy of clock
+func (clock clock) with(opts []Config) *clock {
 	for _, opt := range opts {
-		opt(clock)
+		opt(&clock)
 	}
-	return clock
+	clock.avoidReserved()
+
+	// reserved ranges are shared by clones, append shall not alias them
+	clock.reserved = slices.Clip(clock.reserved)
+	// named sequences are not shared by derived clocks
+	clock.sequences = &sequences{counters: map[string]*int64{}}
+	return &clock
+}
+
+// re-draws random node location while it falls into reserved range
+func (clock *clock) avoidReserved() {
+	for i := 0; clock.random && clock.isReserved(clock.location); i++ {
+		if i == 1024 {
+			panic("guid: unable to allocate node id outside of reserved ranges")
+		}
+		clock.location = randomNode()
+	}
+}
+
+func (clock *clock) isReserved(node uint64) bool {
+	for _, r := range clock.reserved {
+		if r[0] <= node && node <= r[1] {
+			return true
+		}
+	}
+	return false
 }
 
 // Config option of default logical clock behavior.
 // Config options allows to define custom strategies to generate
-// ⟨𝒍⟩ location or ⟨𝒕⟩ timestamp.
+// ⟨𝒍⟩ location or ⟨𝒕⟩ timestamp. Options are applied by NewClock, NewClockMock
+// and Clone to the clock under construction only.
 type Config func(*clock)
 
 // WithNodeID explicitly configures ⟨𝒍⟩ spatially unique identifier
 func WithNodeID(id uint64) Config {
 	return func(clock *clock) {
 		clock.location = id & 0x00000000ffffffff
+		clock.random = false
 	}
 }
 
@@ -96,39 +247,63 @@ func WithNodeFromEnv() Config {
 		h.Write([]byte(os.Getenv("CONFIG_GUID_NODE_ID")))
 		hash := h.Sum(nil)
 		clock.location = uint64(hash[0])<<24 | uint64(hash[1])<<16 | uint64(hash[2])<<8 | uint64(hash[3])
+		clock.random = false
 	}
 }
 
 // WithNodeRandom configures ⟨𝒍⟩ spatially unique identifier using cryptographic random generator
 func WithNodeRandom() Config {
 	return func(clock *clock) {
-		rander := rand.Reader
-		bytes := make([]byte, 8)
-		if _, err := io.ReadFull(rander, bytes); err != nil {
-			panic(err.Error())
-		}
+		clock.location = randomNode()
+		clock.random = true
+	}
+}
 
-		node := uint64(0x0)
-		for i, b := range bytes {
-			node = node | uint64(b)<<(64-8*(i+1))
-		}
-		clock.location = node & 0x00000000ffffffff
+func randomNode() uint64 {
+	rander := rand.Reader
+	bytes := make([]byte, 8)
+	if _, err := io.ReadFull(rander, bytes); err != nil {
+		panic(err.Error())
+	}
+
+	node := uint64(0x0)
+	for i, b := range bytes {
+		node = node | uint64(b)<<(64-8*(i+1))
+	}
+	return node & 0x00000000ffffffff
+}
+
+// WithNodeReservedRange reserves the range [lo, hi] of ⟨𝒍⟩ spatially unique
+// identifiers. Random allocation of node location never draws identifiers
+// from reserved ranges. Organizations dedicate ranges to backfill jobs, test
+// traffic, or specific regions, which configure node explicitly using WithNodeID.
+func WithNodeReservedRange(lo, hi uint32) Config {
+	if lo > hi {
+		panic("guid: invalid reserved range of node id")
+	}
+
+	return func(clock *clock) {
+		clock.reserved = append(clock.reserved, [2]uint64{uint64(lo), uint64(hi)})
 	}
 }
 
 // WithClock configures a custom timestamp generator function
 func WithClock(ticker func() uint64) Config {
 	return func(clock *clock) {
+		clock.source = nil
 		clock.ticker = ticker
 		clock.unique = uniqueInt
+		clock.counter, clock.step = &unique, 1
 	}
 }
 
 // WithClockUnix configures unix timestamp time.Now().UnixNano() as generator function
 func WithClockUnix() Config {
 	return func(clock *clock) {
+		clock.source = nil
 		clock.ticker = unixtime
 		clock.unique = uniqueInt
+		clock.counter, clock.step = &unique, 1
 	}
 }
 
@@ -139,8 +314,10 @@ func unixtime() uint64 {
 // WithClockInverse configures inverse unix timestamp as generator function
 func WithClockInverse() Config {
 	return func(clock *clock) {
+		clock.source = nil
 		clock.ticker = inversetime
 		clock.unique = inverseInt
+		clock.counter, clock.step = &inverse, -1
 	}
 }
 
@@ -152,5 +329,62 @@ func inversetime() uint64 {
 func WithUnique(unique func() uint64) Config {
 	return func(clock *clock) {
 		clock.unique = unique
+		clock.counter, clock.step = nil, 0
+	}
+}
+
+// Errors reported by CheckClock
+var (
+	ErrClockFrozen       = errors.New("guid: clock ticks are constant")
+	ErrClockNonMonotonic = errors.New("guid: clock ticks are not monotonic")
+	ErrNodeZero          = errors.New("guid: node location is zero, global identifiers are not unique")
+	ErrSeqCollision      = errors.New("guid: sequence is not unique")
+)
+
+// CheckClock detects obviously broken configuration of the clock. It samples
+// the clock over short window (about 10 milliseconds), which makes it suitable
+// for running at service startup. All detected issues are joined into error.
+func CheckClock(clock Chronos) error {
+	const (
+		samples = 8
+		burst   = 64
+	)
+
+	var errs []error
+
+	if clock.L() == 0 {
+		errs = append(errs, ErrNodeZero)
+	}
+
+	ticks := make([]uint64, samples)
+	for i := range ticks {
+		ticks[i], _ = clock.T()
+		time.Sleep(time.Millisecond)
+	}
+
+	frozen, asc, desc := true, true, true
+	for i := 1; i < len(ticks); i++ {
+		frozen = frozen && ticks[i] == ticks[0]
+		asc = asc && ticks[i] >= ticks[i-1]
+		desc = desc && ticks[i] <= ticks[i-1]
+	}
+
+	switch {
+	case frozen:
+		errs = append(errs, ErrClockFrozen)
+	case !asc && !desc:
+		errs = append(errs, ErrClockNonMonotonic)
 	}
+
+	seen := make(map[uint64]struct{}, burst)
+	for i := 0; i < burst; i++ {
+		_, seq := clock.T()
+		if _, has := seen[seq]; has {
+			errs = append(errs, ErrSeqCollision)
+			break
+		}
+		seen[seq] = struct{}{}
+	}
+
+	return errors.Join(errs...)
 }
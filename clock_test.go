@@ -19,6 +19,7 @@
 package guid_test
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -62,6 +63,29 @@ func TestWithNodeRand(t *testing.T) {
 	)
 }
 
+func TestWithNodeReservedRange(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		c := guid.NewClock(
+			guid.WithNodeReservedRange(0x00000000, 0x7fffffff),
+			guid.WithNodeReservedRange(0xf0000000, 0xffffffff),
+		)
+		node := guid.Node(guid.G(c))
+
+		it.Then(t).Should(
+			it.True(node >= 0x80000000),
+			it.True(node < 0xf0000000),
+		)
+	}
+
+	c := guid.NewClock(
+		guid.WithNodeReservedRange(0x00000000, 0x7fffffff),
+		guid.WithNodeID(0x7f),
+	)
+	it.Then(t).Should(
+		it.Equal(guid.Node(guid.G(c)), 0x7f),
+	)
+}
+
 func TestWithClock(t *testing.T) {
 	c := guid.NewClock(
 		guid.WithClock(func() uint64 { return 0xfedcba98 << 16 }),
@@ -135,3 +159,110 @@ func TestWithUnique(t *testing.T) {
 		it.Equal(guid.Seq(d), 0),
 	)
 }
+
+func TestDefaultClock(t *testing.T) {
+	it.Then(t).Should(
+		it.Equal(guid.Default(), guid.Clock),
+	)
+
+	var c guid.Chronos = guid.NewClock(guid.WithNodeID(0xfedcba98))
+	guid.SetDefaultClock(c)
+	defer guid.SetDefaultClock(guid.Clock)
+
+	it.Then(t).Should(
+		it.Equal(guid.Default(), c),
+		it.Equal(guid.Node(guid.G(guid.Default())), 0xfedcba98),
+	)
+}
+
+func TestRegisterClock(t *testing.T) {
+	var c guid.Chronos = guid.NewClock(guid.WithClockInverse())
+	guid.RegisterClock("feed", c)
+
+	x, hasX := guid.ClockByName("feed")
+	_, hasY := guid.ClockByName("audit")
+
+	it.Then(t).Should(
+		it.True(hasX),
+		it.Equal(x, c),
+	).ShouldNot(
+		it.True(hasY),
+	)
+}
+
+func TestCheckClock(t *testing.T) {
+	it.Then(t).Should(
+		it.Nil(guid.CheckClock(guid.NewClock())),
+		it.Nil(guid.CheckClock(guid.NewClock(guid.WithClockInverse()))),
+	)
+
+	n := uint64(0)
+	for expect, c := range map[error]guid.Chronos{
+		guid.ErrNodeZero:     guid.NewClock(guid.WithNodeID(0)),
+		guid.ErrClockFrozen:  guid.NewClock(guid.WithClock(func() uint64 { return 1 << 32 })),
+		guid.ErrSeqCollision: guid.NewClock(guid.WithUnique(func() uint64 { return 0 })),
+		guid.ErrClockNonMonotonic: guid.NewClock(guid.WithClock(func() uint64 {
+			n++
+			return (n % 3) << 32
+		})),
+	} {
+		err := guid.CheckClock(c)
+		it.Then(t).Should(
+			it.True(errors.Is(err, expect)),
+		)
+	}
+}
+
+func TestClone(t *testing.T) {
+	a := guid.NewClock(guid.WithNodeID(0xa), guid.WithClockInverse())
+	b := a.Clone(guid.WithNodeID(0xb))
+	x, _ := a.T()
+	y, _ := b.T()
+
+	it.Then(t).Should(
+		it.Equal(a.L(), 0xa),
+		it.Equal(b.L(), 0xb),
+		it.True(y <= x),
+	)
+}
+
+// clock reporting node location consistently via Now only
+type leased struct{ node uint64 }
+
+func (c leased) L() uint64             { return 0 }
+func (c leased) T() (uint64, uint64)   { return 1 << 32, 1 }
+func (c leased) Now() (t, l, s uint64) { return 1 << 32, c.node, 1 }
+
+type plain struct{}
+
+func (plain) L() uint64           { return 0xa }
+func (plain) T() (uint64, uint64) { return 1 << 32, 1 }
+
+func TestObserver(t *testing.T) {
+	t.Run("Now", func(t *testing.T) {
+		uid := guid.G(leased{node: 0xb})
+
+		it.Then(t).Should(
+			it.Equal(guid.Node(uid), 0xb),
+		)
+	})
+
+	t.Run("AsObserver", func(t *testing.T) {
+		tt, l, s := guid.AsObserver(plain{}).Now()
+
+		it.Then(t).Should(
+			it.Equal(tt, 1<<32),
+			it.Equal(l, 0xa),
+			it.Equal(s, 1),
+		)
+	})
+
+	t.Run("Clock", func(t *testing.T) {
+		c := guid.NewClock(guid.WithNodeID(0xc))
+		_, l, _ := guid.AsObserver(c).Now()
+
+		it.Then(t).Should(
+			it.Equal(l, 0xc),
+		)
+	})
+}
@@ -0,0 +1,108 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Command guid-gen emits TypeScript or Python module implementing parse,
+// format and compare of k-ordered identifiers. The module is generated from
+// the wire format of the library (see guid.WireSpec), so that frontends sort
+// and parse identifiers identically.
+//
+//	guid-gen [-lang ts|py] [-o file]
+package main
+
+import (
+	"embed"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/fogfish/guid/v2"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// tables of wire format used by templates
+type tables struct {
+	Version      int
+	Alphabet     string
+	TickBits     int
+	SeqBits      int
+	DriftBits    []int
+	GlobalLength int
+	LocalLength  int
+}
+
+func main() {
+	lang := flag.String("lang", "ts", "target language: ts or py")
+	file := flag.String("o", "", "output file, stdout by default")
+	flag.Parse()
+
+	if err := run(*lang, *file); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(lang, file string) error {
+	tpl, err := template.ParseFS(templates, "templates/"+lang+".tmpl")
+	if err != nil {
+		return fmt.Errorf("unsupported language: %s", lang)
+	}
+
+	var w io.Writer = os.Stdout
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return tpl.Execute(w, tablesOf(guid.WireSpec()))
+}
+
+func tablesOf(spec guid.Spec) tables {
+	t := tables{
+		Version:  spec.Version,
+		TickBits: spec.TickBits,
+	}
+
+	// drift code 0 is not allocated, it decodes as zero point of drift
+	if len(spec.Layouts) > 0 {
+		t.DriftBits = append(t.DriftBits, spec.Layouts[0].DriftBits-spec.Layouts[0].DriftCode)
+	}
+
+	for _, layout := range spec.Layouts {
+		t.DriftBits = append(t.DriftBits, layout.DriftBits)
+		t.SeqBits = layout.Local[len(layout.Local)-1].Bits
+	}
+
+	for _, enc := range spec.Encodings {
+		switch {
+		case enc.Name == "string" && enc.Form == "global":
+			t.Alphabet, t.GlobalLength = enc.Alphabet, enc.Length
+		case enc.Name == "string-local":
+			t.LocalLength = enc.Length
+		}
+	}
+
+	return t
+}
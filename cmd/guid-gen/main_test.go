@@ -0,0 +1,126 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+// golden test of generated python module against guid.TestVectors
+const goldenPy = `import json, sys
+import guid
+
+for i, v in enumerate(json.load(open(sys.argv[1]))):
+    k = int(v["hex"], 16)
+    s = v["string"] if v["global"] else v["string_local"]
+    x = guid.parse(v["string"]) if v["global"] else guid.parse_local(v["string"])
+    checks = [
+        x == k,
+        guid.parse(v["string_local"]) == guid.to_local(k),
+        guid.format(k) == s,
+        guid.parse(s) == k,
+        guid.is_global(k) == v["global"],
+        guid.time(k) == v["unix_nano"] >> guid.TICK_BITS << guid.TICK_BITS,
+        guid.node(k) == v["node"],
+        guid.seq(k) == v["seq"],
+    ]
+    if v["global"]:
+        checks.append(guid.parse("*" + v["string"]) == guid.to_local(k))
+    for c, ok in enumerate(checks):
+        if not ok:
+            print(f"vector {i}: check {c} failed")
+`
+
+// golden test of generated typescript module against guid.TestVectors
+const goldenTs = `import { readFileSync } from "node:fs";
+import * as guid from "./guid.ts";
+
+const vectors = JSON.parse(readFileSync(process.argv[2], "utf8"));
+vectors.forEach((v: any, i: number) => {
+  const k = BigInt("0x" + v.hex);
+  const s = v.global ? v.string : v.string_local;
+  const x = v.global ? guid.parse(v.string) : guid.parseLocal(v.string);
+  const checks = [
+    x === k,
+    guid.parse(v.string_local) === guid.toLocal(k),
+    guid.format(k) === s,
+    guid.parse(s) === k,
+    guid.isGlobal(k) === v.global,
+    guid.time(k) === (BigInt(v.unix_nano) >> 17n) << 17n,
+    guid.node(k) === v.node,
+    guid.seq(k) === v.seq,
+  ];
+  if (v.global) {
+    checks.push(guid.parse("*" + v.string) === guid.toLocal(k));
+  }
+  checks.forEach((ok, c) => {
+    if (!ok) {
+      console.log(` + "`vector ${i}: check ${c} failed`" + `);
+    }
+  });
+});
+`
+
+func golden(t *testing.T, lang, module, driver string, cmd ...string) {
+	t.Helper()
+
+	if _, err := exec.LookPath(cmd[0]); err != nil {
+		t.Skipf("%s is not available", cmd[0])
+	}
+
+	dir := t.TempDir()
+	vectors, err := json.Marshal(guid.TestVectors())
+	it.Then(t).Should(it.Nil(err))
+
+	it.Then(t).Should(
+		it.Nil(run(lang, filepath.Join(dir, module))),
+		it.Nil(os.WriteFile(filepath.Join(dir, "vectors.json"), vectors, 0o644)),
+		it.Nil(os.WriteFile(filepath.Join(dir, "golden."+lang), []byte(driver), 0o644)),
+	)
+
+	args := append(cmd[1:], "golden."+lang, "vectors.json")
+	sh := exec.Command(cmd[0], args...)
+	sh.Dir = dir
+	out, err := sh.CombinedOutput()
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(strings.TrimSpace(string(out)), ""),
+	)
+}
+
+func TestGoldenPy(t *testing.T) {
+	golden(t, "py", "guid.py", goldenPy, "python3")
+}
+
+func TestGoldenTs(t *testing.T) {
+	// typescript is executed by node with type stripping (node 22.6+)
+	if err := exec.Command("node", "--experimental-strip-types", "-e", "").Run(); err != nil {
+		t.Skip("node does not support type stripping")
+	}
+
+	golden(t, "ts", "guid.ts", goldenTs, "node", "--experimental-strip-types", "--no-warnings")
+}
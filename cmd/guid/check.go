@@ -0,0 +1,80 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strings"
+
+	"github.com/fogfish/guid/v2"
+)
+
+func check(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	k := fs.Int("k", 1, "k-order window")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var failure error
+	err := guid.CheckOrder(scan(os.Stdin, &failure), *k)
+	if failure != nil {
+		return failure
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "stream is %d-ordered\n", *k)
+	return nil
+}
+
+// scan reads identifiers from the stream, one per line
+func scan(r io.Reader, failure *error) iter.Seq[guid.K] {
+	return func(yield func(guid.K) bool) {
+		line := 0
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line++
+			txt := strings.TrimSpace(scanner.Text())
+			if txt == "" {
+				continue
+			}
+
+			uid, _, err := parse(txt)
+			if err != nil {
+				*failure = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+
+			if !yield(uid) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			*failure = err
+		}
+	}
+}
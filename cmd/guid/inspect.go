@@ -0,0 +1,67 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/fogfish/guid/v2"
+)
+
+func inspect(args []string) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "output structured JSON breakdown")
+	to := fs.String("to", "", "output identifier in the format (string, base62, hex, uuid)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	for _, arg := range fs.Args() {
+		uid, format, err := parse(arg)
+		if err != nil {
+			return err
+		}
+
+		if *to != "" {
+			s, err := guid.EncodeFormat(uid, guid.Format(*to))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stdout, s)
+			continue
+		}
+
+		if *asJSON {
+			b, err := guid.DissectorJSON(guid.Bytes(uid))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stdout, string(b))
+			continue
+		}
+
+		x := guid.Dissect(uid)
+		fmt.Fprintf(os.Stdout, "%s %s\n  enc   %s\n  time  %s\n  node  %08x\n  seq   %d\n  drift %s\n",
+			x.Type, x.ID, format, x.Time.Format("2006-01-02T15:04:05.000000Z07:00"), x.Node, x.Seq, x.Drift)
+	}
+
+	return nil
+}
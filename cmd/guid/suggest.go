@@ -0,0 +1,151 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// alphabet of lexicographically sortable strings
+const alphabet = ".0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+// groups of characters confused by transcription
+var lookalikes = []string{"0Oo", "1lI", "5Ss", "2Zz", "8B", "6G", "9g", "uvUV", "._-,"}
+
+// parse decodes identifier from any registered format (see guid.ParseAny),
+// malformed one is reported together with nearest valid canonical forms
+// (single-char edits within alphabet)
+func parse(arg string) (guid.K, guid.Format, error) {
+	uid, err := decode(arg)
+	if err == nil {
+		return uid, guid.FormatString, nil
+	}
+
+	if uid, format, e := guid.ParseAny(arg); e == nil {
+		return uid, format, nil
+	}
+
+	if seq := suggest(arg, 3); len(seq) > 0 {
+		return guid.K{}, "", fmt.Errorf("%w, did you mean %s?", err, strings.Join(seq, " or "))
+	}
+	return guid.K{}, "", err
+}
+
+// decode canonical identifier, the characters are validated strictly
+func decode(arg string) (guid.K, error) {
+	for i, r := range arg {
+		if !strings.ContainsRune(alphabet, r) && !(i == 0 && r == '*') {
+			return guid.K{}, fmt.Errorf("malformed k-order number: %v (invalid character %q)", arg, r)
+		}
+	}
+
+	return guid.Strict(guid.FromString(arg))
+}
+
+// suggest returns valid identifiers within edit distance 1 of the argument,
+// only the most plausible edits are suggested. Nothing is suggested if there
+// are more than n equally plausible edits.
+func suggest(arg string, n int) []string {
+	type candidate struct {
+		val   string
+		score int
+	}
+
+	seq := []candidate{}
+	seen := map[string]bool{}
+	try := func(val string, score int) {
+		if seen[val] {
+			return
+		}
+		seen[val] = true
+
+		if _, err := decode(val); err == nil {
+			seq = append(seq, candidate{val, score})
+		}
+	}
+
+	// substitutions
+	for i, c := range []byte(arg) {
+		valid := strings.IndexByte(alphabet, c) >= 0
+		for _, x := range []byte(alphabet) {
+			switch {
+			case x == c:
+				continue
+			case alike(c, x) && !valid:
+				try(arg[:i]+string(x)+arg[i+1:], 0)
+			case alike(c, x):
+				try(arg[:i]+string(x)+arg[i+1:], 1)
+			case !valid:
+				try(arg[:i]+string(x)+arg[i+1:], 2)
+			default:
+				try(arg[:i]+string(x)+arg[i+1:], 3)
+			}
+		}
+	}
+
+	// deletions, doubled characters are likely typos
+	for i := range arg {
+		score := 2
+		if (i > 0 && arg[i-1] == arg[i]) || (i+1 < len(arg) && arg[i+1] == arg[i]) {
+			score = 1
+		}
+		try(arg[:i]+arg[i+1:], score)
+	}
+
+	// insertions
+	for i := 0; i <= len(arg); i++ {
+		for _, x := range []byte(alphabet) {
+			try(arg[:i]+string(x)+arg[i:], 2)
+		}
+	}
+
+	if len(seq) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(seq, func(i, j int) bool { return seq[i].score < seq[j].score })
+
+	best := []string{}
+	for _, c := range seq {
+		if c.score != seq[0].score {
+			break
+		}
+		best = append(best, c.val)
+	}
+
+	// the edit is ambiguous
+	if len(best) > n {
+		return nil
+	}
+	return best
+}
+
+// alike checks if characters are confused by transcription
+func alike(a, b byte) bool {
+	for _, group := range lookalikes {
+		if strings.IndexByte(group, a) >= 0 && strings.IndexByte(group, b) >= 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/fogfish/it/v2"
+)
+
+func TestSuggest(t *testing.T) {
+	// only insertions at the head are canonical, drift bits are not zero
+	insertions := []string{}
+	for _, x := range alphabet[8:] {
+		insertions = append(insertions, string(x)+".ljBVm51PwMrZR.")
+	}
+
+	for _, tc := range []struct {
+		arg    string
+		n      int
+		expect []string
+	}{
+		// substitution of lookalike
+		{"NljBVm51PwMrZR-1", 3, []string{"NljBVm51PwMrZR.1", "NljBVm51PwMrZR_1"}},
+		{"NljBVm51PwMrZR,1", 3, []string{"NljBVm51PwMrZR.1", "NljBVm51PwMrZR_1"}},
+		// deletion of doubled character
+		{"NljBVm51PwMrZR.11", 3, []string{"NljBVm51PwMrZR.1"}},
+		// insertion
+		{".ljBVm51PwMrZR.", 64, insertions},
+		// ambiguous edits
+		{".ljBVm51PwMrZR.", 3, nil},
+		{"NljBVm51PwMrZR.", 3, nil},
+		{"NljBVm5lPwMrZR.1", 3, nil},
+	} {
+		it.Then(t).Should(
+			it.Seq(suggest(tc.arg, tc.n)).Equal(tc.expect...),
+		)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package columnar
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/gocql/gocql"
+)
+
+// Cassandra is a codec of k-ordered value to Cassandra columns, it implements
+// gocql Marshaler and Unmarshaler interfaces. The codec supports
+//
+//	blob    - 12 bytes big-endian binary, the native choice for clustering keys
+//	bigint  - local (64-bit) values only, sign bit is flipped to preserve ordering
+//	text    - global (96-bit) values only, lexicographically sortable string
+type Cassandra guid.K
+
+// MarshalCQL encodes k-ordered value to Cassandra type
+func (uid Cassandra) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	switch info.Type() {
+	case gocql.TypeBlob:
+		return fixed(guid.K(uid)), nil
+	case gocql.TypeBigInt:
+		if uid.Hi != 0 {
+			return nil, fmt.Errorf("global k-order number %v cannot be stored as bigint", guid.K(uid))
+		}
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, uid.Lo^(1<<63))
+		return b, nil
+	case gocql.TypeVarchar, gocql.TypeText, gocql.TypeAscii:
+		if uid.Hi == 0 {
+			return nil, fmt.Errorf("local k-order number %v cannot be stored as text", guid.K(uid))
+		}
+		return []byte(guid.String(guid.K(uid))), nil
+	default:
+		return nil, fmt.Errorf("k-order number cannot be stored as %s", info.Type())
+	}
+}
+
+// UnmarshalCQL decodes k-ordered value from Cassandra type
+func (uid *Cassandra) UnmarshalCQL(info gocql.TypeInfo, data []byte) (err error) {
+	var val guid.K
+
+	switch info.Type() {
+	case gocql.TypeBlob:
+		val, err = fromFixed(data)
+	case gocql.TypeBigInt:
+		if len(data) != 8 {
+			return fmt.Errorf("malformed k-order number: %v", data)
+		}
+		val = guid.K{Lo: binary.BigEndian.Uint64(data) ^ (1 << 63)}
+	case gocql.TypeVarchar, gocql.TypeText, gocql.TypeAscii:
+		val, err = guid.FromStringG(string(data))
+	default:
+		err = fmt.Errorf("k-order number cannot be decoded from %s", info.Type())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*uid = Cassandra(val)
+	return nil
+}
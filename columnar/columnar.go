@@ -0,0 +1,108 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package columnar implements codecs of k-ordered values for columnar and
+// wide-column databases. The codecs keep binary layout of k-ordered values
+// big-endian so that database native ordering of columns is consistent with
+// ordering of identifiers, which makes identifiers friendly for range scans.
+package columnar
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// size of FixedString(12) column
+const fixedSize = 12
+
+// fixed encodes k-ordered value to 12 bytes, local values are padded with zeros
+func fixed(uid guid.K) []byte {
+	b := make([]byte, fixedSize)
+	binary.BigEndian.PutUint32(b[0:4], uint32(uid.Hi))
+	binary.BigEndian.PutUint64(b[4:12], uid.Lo)
+	return b
+}
+
+func fromFixed(b []byte) (guid.K, error) {
+	if len(b) != fixedSize {
+		return guid.K{}, fmt.Errorf("malformed k-order number: %v", b)
+	}
+
+	return guid.K{
+		Hi: uint64(binary.BigEndian.Uint32(b[0:4])),
+		Lo: binary.BigEndian.Uint64(b[4:12]),
+	}, nil
+}
+
+//------------------------------------------------------------------------------
+//
+// ClickHouse
+//
+//------------------------------------------------------------------------------
+
+// ClickHouse is a codec of k-ordered value to ClickHouse FixedString(12) column.
+// The type implements database/sql Valuer and Scanner interfaces.
+//
+//	CREATE TABLE events (id FixedString(12), ...) ORDER BY id
+type ClickHouse guid.K
+
+// Value encodes k-ordered value to FixedString(12)
+func (uid ClickHouse) Value() (driver.Value, error) {
+	return fixed(guid.K(uid)), nil
+}
+
+// Scan decodes k-ordered value from FixedString(12)
+func (uid *ClickHouse) Scan(src any) (err error) {
+	var val guid.K
+
+	switch v := src.(type) {
+	case []byte:
+		val, err = fromFixed(v)
+	case string:
+		val, err = fromFixed([]byte(v))
+	default:
+		err = fmt.Errorf("unsupported type %T for k-order number", src)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*uid = ClickHouse(val)
+	return nil
+}
+
+// ToUInt64Pair encodes k-ordered value as pair of UInt64 columns, the pair
+// is sortable using composite key
+//
+//	CREATE TABLE events (id_hi UInt64, id_lo UInt64, ...) ORDER BY (id_hi, id_lo)
+func ToUInt64Pair(uid guid.K) (hi, lo uint64) {
+	return uid.Hi & 0xffffffff, uid.Lo
+}
+
+// FromUInt64Pair decodes k-ordered value from pair of UInt64 columns
+func FromUInt64Pair(hi, lo uint64) (guid.K, error) {
+	if hi>>32 != 0 {
+		return guid.K{}, fmt.Errorf("malformed k-order number: %x%016x", hi, lo)
+	}
+
+	return guid.K{Hi: hi, Lo: lo}, nil
+}
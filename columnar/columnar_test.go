@@ -0,0 +1,118 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package columnar_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fogfish/guid/columnar"
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+	"github.com/gocql/gocql"
+)
+
+func TestClickHouse(t *testing.T) {
+	c := guid.NewClock()
+
+	for _, a := range []guid.K{guid.G(c), guid.L(c)} {
+		v, err := columnar.ClickHouse(a).Value()
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(len(v.([]byte)), 12),
+		)
+
+		var b columnar.ClickHouse
+		err = b.Scan(v)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(guid.K(b), a),
+		)
+	}
+
+	t.Run("Ordering", func(t *testing.T) {
+		a, _ := columnar.ClickHouse(guid.G(c)).Value()
+		b, _ := columnar.ClickHouse(guid.G(c)).Value()
+		it.Then(t).Should(
+			it.True(bytes.Compare(a.([]byte), b.([]byte)) < 0),
+		)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var b columnar.ClickHouse
+		it.Then(t).ShouldNot(
+			it.Nil(b.Scan([]byte{1, 2, 3})),
+			it.Nil(b.Scan(12)),
+		)
+	})
+}
+
+func TestUInt64Pair(t *testing.T) {
+	a := guid.G(guid.NewClock())
+	hi, lo := columnar.ToUInt64Pair(a)
+	b, err := columnar.FromUInt64Pair(hi, lo)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(b, a),
+	)
+
+	_, err = columnar.FromUInt64Pair(1<<32, 0)
+	it.Then(t).ShouldNot(it.Nil(err))
+}
+
+func TestCassandra(t *testing.T) {
+	c := guid.NewClock()
+
+	for typ, a := range map[gocql.Type]guid.K{
+		gocql.TypeBlob:    guid.G(c),
+		gocql.TypeBigInt:  guid.L(c),
+		gocql.TypeVarchar: guid.G(c),
+	} {
+		info := gocql.NewNativeType(4, typ, "")
+		data, err := gocql.Marshal(info, columnar.Cassandra(a))
+		it.Then(t).Should(it.Nil(err))
+
+		var b columnar.Cassandra
+		err = gocql.Unmarshal(info, data, &b)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(guid.K(b), a),
+		)
+	}
+
+	t.Run("BigIntOrdering", func(t *testing.T) {
+		info := gocql.NewNativeType(4, gocql.TypeBigInt, "")
+		a, _ := columnar.Cassandra(guid.L(c)).MarshalCQL(info)
+		b, _ := columnar.Cassandra(guid.L(c)).MarshalCQL(info)
+
+		var x, y int64
+		gocql.Unmarshal(info, a, &x)
+		gocql.Unmarshal(info, b, &y)
+		it.Then(t).Should(it.True(x < y))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := columnar.Cassandra(guid.G(c)).MarshalCQL(gocql.NewNativeType(4, gocql.TypeBigInt, ""))
+		it.Then(t).ShouldNot(it.Nil(err))
+
+		_, err = columnar.Cassandra(guid.G(c)).MarshalCQL(gocql.NewNativeType(4, gocql.TypeInt, ""))
+		it.Then(t).ShouldNot(it.Nil(err))
+	})
+}
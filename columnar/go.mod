@@ -0,0 +1,17 @@
+module github.com/fogfish/guid/columnar
+
+go 1.23
+
+require (
+	github.com/fogfish/guid/v2 v2.0.0
+	github.com/fogfish/it/v2 v2.0.1
+	github.com/gocql/gocql v1.7.0
+)
+
+require (
+	github.com/golang/snappy v0.0.3 // indirect
+	github.com/hailocab/go-hostpool v0.0.0-20160125115350-e80d13ce29ed // indirect
+	gopkg.in/inf.v0 v0.9.1 // indirect
+)
+
+replace github.com/fogfish/guid/v2 => ../
@@ -25,6 +25,21 @@ import (
 // zero point for drift
 const driftZ = 18
 
+// driftSteps is the maximal time drift tolerated by each value of drift bits,
+// the step i corresponds to driftZ + i + 1. The step is the duration of
+// 2ⁿ ticks of ⟨𝒕⟩ fraction, where n is number of drift bits.
+var driftSteps = [...]time.Duration{
+	// NOTE: zero drift is not allowed
+	// 34 * time.Second,
+	68 * time.Second,
+	137 * time.Second,
+	274 * time.Second,
+	549 * time.Second,
+	1099 * time.Second,
+	2199 * time.Second,
+	4398 * time.Second,
+}
+
 // driftBits converts a time drift into number of bits to shift the location
 // fraction. E.g. if application allows 2 min time drift in the system than last
 // 20 bits of timestamp becomes less significant than location.
@@ -32,29 +47,59 @@ const driftZ = 18
 // The default drift is approximately 5 min, the drift value is encoded as
 // 3 bits, which gives 7 possible values (zero drift not allowed)
 func driftInBits(drift []time.Duration) uint64 {
-	switch {
-	case len(drift) == 0:
-		return driftZ + 3
-	// NOTE: allow only 1 - 7 values for drift
-	// case drift[0] <= 34*time.Second:
-	// return driftZ
-	case drift[0] <= 68*time.Second:
-		return driftZ + 1
-	case drift[0] <= 137*time.Second:
-		return driftZ + 2
-	case drift[0] <= 274*time.Second:
+	if len(drift) == 0 {
 		return driftZ + 3
-	case drift[0] <= 549*time.Second:
-		return driftZ + 4
-	case drift[0] <= 1099*time.Second:
-		return driftZ + 5
-	case drift[0] <= 2199*time.Second:
-		return driftZ + 6
-	default:
-		return driftZ + 7
 	}
+
+	for i, step := range driftSteps[:len(driftSteps)-1] {
+		if drift[0] <= step {
+			return driftZ + uint64(i) + 1
+		}
+	}
+
+	return driftZ + uint64(len(driftSteps))
+}
+
+// driftOf decodes drift bits from k-ordered value
+func driftOf(uid K) uint64 {
+	if uid.Hi == 0 {
+		return (uid.Lo >> 61) + driftZ
+	}
+	return (uid.Hi>>29)&7 + driftZ
 }
 
+// driftStepOf returns drift step for drift bits
+func driftStepOf(drift uint64) time.Duration {
+	if drift <= driftZ {
+		return 0
+	}
+	return driftSteps[drift-driftZ-1]
+}
+
+// layout is shift parameters of ⟨𝒕⟩ and ⟨𝒍⟩ fractions, precomputed for each
+// value of drift bits
+type layout struct {
+	d   uint64 // width of ⟨𝒕⟩ low fraction
+	thi uint64 // shift of ⟨𝒕⟩ high fraction within Hi
+	tlo uint64 // mask of ⟨𝒕⟩ low fraction
+	nlo uint64 // shift of ⟨𝒍⟩ low fraction within Lo
+	nhi uint64 // shift of ⟨𝒍⟩ high fraction within Hi
+}
+
+var layouts = func() (seq [8]layout) {
+	for i := range seq {
+		d := uint64(driftZ + i)
+		seq[i] = layout{
+			d:   d,
+			thi: d - driftZ,
+			tlo: 1<<d - 1,
+			nlo: bitsSeq + d,
+			nhi: 64 - bitsSeq - d,
+		}
+	}
+	return
+}()
+
 // splits ⟨𝒕⟩ faction (timestamp) to hi and lo bits of K order value
 func splitT(t uint64, drift uint64) (uint64, uint64) {
 	//
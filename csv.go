@@ -0,0 +1,80 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/csv"
+	"fmt"
+)
+
+// CSVField encodes k-ordered value as CSV field, the field is decoded by
+// FromString. The encoding does not require quoting. Local values are encoded
+// in their canonical local string (see StringLocal), the field does not
+// depend on SetPromoteOnMarshal.
+func CSVField(uid K) string {
+	return stableString(uid)
+}
+
+// RecordDecoder parses k-ordered values from chosen columns of CSV records
+type RecordDecoder struct {
+	reader  *csv.Reader
+	columns []int
+}
+
+// NewRecordDecoder creates decoder of identifiers from the columns of CSV records
+func NewRecordDecoder(reader *csv.Reader, columns ...int) *RecordDecoder {
+	return &RecordDecoder{reader: reader, columns: columns}
+}
+
+// Decode reads next record, returning identifiers decoded from chosen columns
+// and the record itself. Fields are validated strictly, empty, malformed and
+// non-canonical fields are reported as *csv.ParseError with its position.
+// It returns io.EOF when no more records are available.
+func (dec *RecordDecoder) Decode() ([]K, []string, error) {
+	record, err := dec.reader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]K, len(dec.columns))
+	for i, col := range dec.columns {
+		if col < 0 || col >= len(record) {
+			line, _ := dec.reader.FieldPos(0)
+			return nil, record, &csv.ParseError{
+				StartLine: line,
+				Line:      line,
+				Err:       fmt.Errorf("column %d is out of record of %d fields", col, len(record)),
+			}
+		}
+
+		uid, err := decodeStrict(record[col])
+		if err != nil {
+			line, column := dec.reader.FieldPos(col)
+			return nil, record, &csv.ParseError{
+				StartLine: line,
+				Line:      line,
+				Column:    column,
+				Err:       err,
+			}
+		}
+		ids[i] = uid
+	}
+
+	return ids, record, nil
+}
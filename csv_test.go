@@ -0,0 +1,117 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestCSV(t *testing.T) {
+	g := guid.G(guid.Clock)
+	p := guid.G(guid.Clock)
+
+	t.Run("Decode", func(t *testing.T) {
+		data := "a," + guid.CSVField(g) + "," + guid.CSVField(p) + "\n" +
+			"b," + guid.CSVField(p) + "," + guid.CSVField(g) + "\n"
+
+		dec := guid.NewRecordDecoder(csv.NewReader(strings.NewReader(data)), 1, 2)
+
+		a, ra, erra := dec.Decode()
+		b, rb, errb := dec.Decode()
+		_, _, eof := dec.Decode()
+
+		it.Then(t).Should(
+			it.Nil(erra),
+			it.Seq(a).Equal(g, p),
+			it.Equal(ra[0], "a"),
+			it.Nil(errb),
+			it.Seq(b).Equal(p, g),
+			it.Equal(rb[0], "b"),
+			it.True(errors.Is(eof, io.EOF)),
+		)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		data := "a," + guid.CSVField(g) + "\n" + "b,foo\n"
+		dec := guid.NewRecordDecoder(csv.NewReader(strings.NewReader(data)), 1)
+
+		_, _, err := dec.Decode()
+		it.Then(t).Should(it.Nil(err))
+
+		_, _, err = dec.Decode()
+		var e *csv.ParseError
+		it.Then(t).Should(
+			it.True(errors.As(err, &e)),
+			it.Equal(e.Line, 2),
+			it.Equal(e.Column, 3),
+		)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, field := range []string{
+			"",
+			"!!!!!!!!!!!!!!!!",
+			"????????????????",
+			"*!!!!!!!!!!!!!!!!",
+			"!!!!!!!!!!!",
+		} {
+			data := "a," + field + "\n"
+			dec := guid.NewRecordDecoder(csv.NewReader(strings.NewReader(data)), 1)
+
+			_, _, err := dec.Decode()
+			var e *csv.ParseError
+			it.Then(t).Should(
+				it.True(errors.As(err, &e)),
+				it.Equal(e.Line, 1),
+			)
+		}
+	})
+
+	t.Run("Promote", func(t *testing.T) {
+		l := guid.L(guid.NewClock(guid.WithNodeID(0xa)))
+		field := guid.CSVField(l)
+
+		guid.SetPromoteOnMarshal(true)
+		defer guid.SetPromoteOnMarshal(false)
+
+		data := "a," + guid.CSVField(l) + "\n"
+		dec := guid.NewRecordDecoder(csv.NewReader(strings.NewReader(data)), 1)
+
+		a, _, err := dec.Decode()
+		it.Then(t).Should(
+			it.Equal(guid.CSVField(l), field),
+			it.Nil(err),
+			it.Seq(a).Equal(l),
+		)
+	})
+
+	t.Run("Column", func(t *testing.T) {
+		dec := guid.NewRecordDecoder(csv.NewReader(strings.NewReader("a\n")), 1)
+
+		_, _, err := dec.Decode()
+		it.Then(t).ShouldNot(it.Nil(err))
+	})
+}
@@ -0,0 +1,104 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Cursor is codec of opaque, URL-safe pagination cursors built from the last
+// k-ordered value of the page. Cursors are signed when keys are defined,
+// the first key signs cursors, all keys verify them.
+type Cursor struct {
+	Keys [][]byte
+}
+
+// Encode cursor of the last identifier on the page with extra parameters.
+// Local values are encoded in their canonical local string (see StringLocal),
+// the cursor does not depend on SetPromoteOnMarshal.
+func (c Cursor) Encode(last K, extra map[string]string) string {
+	q := url.Values{}
+	for k, v := range extra {
+		q.Set(k, v)
+	}
+
+	val := stableString(last) + "?" + q.Encode()
+	if len(c.Keys) > 0 {
+		val = val + "#" + base64.RawURLEncoding.EncodeToString(signature(c.Keys[0], val))
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(val))
+}
+
+// Decode cursor into the last identifier and extra parameters
+func (c Cursor) Decode(s string) (K, map[string]string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return K{}, nil, fmt.Errorf("malformed cursor: %w", err)
+	}
+	val := string(b)
+
+	if len(c.Keys) > 0 {
+		at := strings.LastIndexByte(val, '#')
+		if at < 0 {
+			return K{}, nil, ErrSignature
+		}
+
+		mac, err := base64.RawURLEncoding.DecodeString(val[at+1:])
+		if err != nil || !c.verify(val[:at], mac) {
+			return K{}, nil, ErrSignature
+		}
+		val = val[:at]
+	}
+
+	id, query, has := strings.Cut(val, "?")
+	if !has {
+		return K{}, nil, fmt.Errorf("malformed cursor: %s", s)
+	}
+
+	last, err := FromString(id)
+	if err != nil {
+		return K{}, nil, err
+	}
+
+	q, err := url.ParseQuery(query)
+	if err != nil {
+		return K{}, nil, fmt.Errorf("malformed cursor: %w", err)
+	}
+
+	extra := make(map[string]string, len(q))
+	for k := range q {
+		extra[k] = q.Get(k)
+	}
+
+	return last, extra, nil
+}
+
+func (c Cursor) verify(val string, mac []byte) bool {
+	for _, key := range c.Keys {
+		if hmac.Equal(mac, signature(key, val)) {
+			return true
+		}
+	}
+	return false
+}
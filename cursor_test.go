@@ -0,0 +1,89 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestCursor(t *testing.T) {
+	last := guid.G(guid.Clock)
+	extra := map[string]string{"sort": "desc", "q": "a b&c"}
+
+	t.Run("Plain", func(t *testing.T) {
+		c := guid.Cursor{}
+		uid, x, err := c.Decode(c.Encode(last, extra))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(uid, last),
+			it.Equal(x["sort"], "desc"),
+			it.Equal(x["q"], "a b&c"),
+		)
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		c := guid.Cursor{Keys: [][]byte{[]byte("key-1")}}
+		s := c.Encode(last, nil)
+
+		uid, x, err := c.Decode(s)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(uid, last),
+			it.Equal(len(x), 0),
+		)
+
+		_, _, err = guid.Cursor{Keys: [][]byte{[]byte("key-2")}}.Decode(s)
+		it.Then(t).Should(
+			it.True(errors.Is(err, guid.ErrSignature)),
+		)
+
+		_, _, err = c.Decode(guid.Cursor{}.Encode(last, nil))
+		it.Then(t).Should(
+			it.True(errors.Is(err, guid.ErrSignature)),
+		)
+	})
+
+	t.Run("Promote", func(t *testing.T) {
+		c := guid.Cursor{Keys: [][]byte{[]byte("key-1")}}
+		l := guid.L(guid.NewClock(guid.WithNodeID(0xa)))
+		s := c.Encode(l, nil)
+
+		guid.SetPromoteOnMarshal(true)
+		defer guid.SetPromoteOnMarshal(false)
+
+		uid, _, err := c.Decode(c.Encode(l, nil))
+		it.Then(t).Should(
+			it.Equal(c.Encode(l, nil), s),
+			it.Nil(err),
+			it.Equal(uid, l),
+		)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, _, err := guid.Cursor{}.Decode("!!!")
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	})
+}
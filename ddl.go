@@ -0,0 +1,93 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "fmt"
+
+// SQL dialects supported by DDL generator
+const (
+	Postgres = "postgres"
+	MySQL    = "mysql"
+	SQLite   = "sqlite"
+)
+
+// DDL returns recommended column definitions for k-ordered values in the
+// given SQL dialect. The binary column stores 12 bytes of Bytes (8 bytes of
+// local values), the text column stores 16 characters of String, both
+// preserve the k-order under byte-wise comparison so that B-tree indexes are
+// range scanned by time. Unknown dialect is reported as error.
+func DDL(dialect string) (string, error) {
+	check, err := DDLCheck(dialect, "id", false)
+	if err != nil {
+		return "", err
+	}
+
+	text, err := DDLCheck(dialect, "id", true)
+	if err != nil {
+		return "", err
+	}
+
+	switch dialect {
+	case Postgres:
+		return `-- binary encoding (guid.Bytes), 12 bytes, 8 bytes of local values
+id bytea NOT NULL ` + check + `
+-- text encoding (guid.String), byte-wise collation keeps the order
+id char(16) COLLATE "C" NOT NULL ` + text + `
+-- index: PRIMARY KEY (id) or CREATE INDEX ... USING btree (id)
+`, nil
+	case MySQL:
+		return `-- binary encoding (guid.Bytes), 12 bytes, 8 bytes of local values
+id varbinary(12) NOT NULL ` + check + `
+-- text encoding (guid.String), byte-wise collation keeps the order
+id char(16) CHARACTER SET ascii COLLATE ascii_bin NOT NULL ` + text + `
+-- index: PRIMARY KEY (id), InnoDB clusters rows in k-order
+`, nil
+	case SQLite:
+		return `-- binary encoding (guid.Bytes), 12 bytes, 8 bytes of local values
+id blob NOT NULL ` + check + `
+-- text encoding (guid.String), default BINARY collation keeps the order
+id text NOT NULL ` + text + `
+-- index: PRIMARY KEY (id) WITHOUT ROWID
+`, nil
+	default:
+		return "", fmt.Errorf("unknown dialect: %s", dialect)
+	}
+}
+
+// DDLCheck returns CHECK constraint of canonical encoding for the column,
+// either binary (guid.Bytes) or text (guid.String). Binary encoding is 12
+// bytes of global values or 8 bytes of local ones.
+func DDLCheck(dialect, column string, text bool) (string, error) {
+	switch {
+	case dialect == Postgres && text:
+		return fmt.Sprintf("CHECK (%s ~ '^[.0-9A-Z_a-z]{16}$')", column), nil
+	case dialect == Postgres:
+		return fmt.Sprintf("CHECK (octet_length(%s) IN (8, 12))", column), nil
+	case dialect == MySQL && text:
+		return fmt.Sprintf("CHECK (%s REGEXP BINARY '^[.0-9A-Z_a-z]{16}$')", column), nil
+	case dialect == MySQL:
+		return fmt.Sprintf("CHECK (length(%s) IN (8, 12))", column), nil
+	case dialect == SQLite && text:
+		return fmt.Sprintf("CHECK (length(%[1]s) = 16 AND %[1]s NOT GLOB '*[^.0-9A-Z_a-z]*')", column), nil
+	case dialect == SQLite:
+		return fmt.Sprintf("CHECK (length(%s) IN (8, 12))", column), nil
+	default:
+		return "", fmt.Errorf("unknown dialect: %s", dialect)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestDDL(t *testing.T) {
+	for dialect, column := range map[string]string{
+		guid.Postgres: "bytea",
+		guid.MySQL:    "varbinary(12)",
+		guid.SQLite:   "blob",
+	} {
+		ddl, err := guid.DDL(dialect)
+		check, _ := guid.DDLCheck(dialect, "id", true)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(strings.Contains(ddl, "id "+column+" NOT NULL")),
+			it.True(strings.Contains(ddl, check)),
+		)
+	}
+
+	check, err := guid.DDLCheck(guid.Postgres, "uid", false)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(check, "CHECK (octet_length(uid) IN (8, 12))"),
+	)
+
+	_, errDDL := guid.DDL("oracle")
+	_, errCheck := guid.DDLCheck("oracle", "id", true)
+	it.Then(t).ShouldNot(
+		it.Nil(errDDL),
+		it.Nil(errCheck),
+	)
+}
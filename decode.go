@@ -0,0 +1,94 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"bytes"
+	"fmt"
+	"unsafe"
+)
+
+// DecodeError reports the position of malformed record in the batch
+type DecodeError struct {
+	Index int
+	Err   error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.Index, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// DecodeAll decodes batch of lexicographically sortable strings (see FromString).
+// The output is allocated once, decoding stops at first malformed or empty record.
+func DecodeAll(lines []string) ([]K, error) {
+	seq := make([]K, len(lines))
+	for i, line := range lines {
+		uid, err := fromStringNonEmpty(line)
+		if err != nil {
+			return seq[:i], &DecodeError{Index: i, Err: err}
+		}
+		seq[i] = uid
+	}
+
+	return seq, nil
+}
+
+// DecodeAllBytes decodes batch of fixed-width records, each stride bytes long.
+// Records shorter than 16 bytes are binary (see FromBytes), otherwise records
+// are strings (see FromString) padded with white spaces or new lines.
+// The output is allocated once, decoding stops at first malformed or blank record.
+func DecodeAllBytes(b []byte, stride int) ([]K, error) {
+	if stride <= 0 || len(b)%stride != 0 {
+		return nil, fmt.Errorf("malformed k-order batch: %d bytes, stride %d", len(b), stride)
+	}
+
+	seq := make([]K, len(b)/stride)
+	for i := range seq {
+		var (
+			rec = b[i*stride : (i+1)*stride]
+			uid K
+			err error
+		)
+
+		if stride < 16 {
+			uid, err = FromBytes(rec)
+		} else {
+			rec = bytes.TrimRight(rec, " \t\r\n")
+			uid, err = fromStringNonEmpty(*(*string)(unsafe.Pointer(&rec)))
+		}
+
+		if err != nil {
+			return seq[:i], &DecodeError{Index: i, Err: err}
+		}
+		seq[i] = uid
+	}
+
+	return seq, nil
+}
+
+// fromStringNonEmpty decodes lexicographically sortable string, empty string
+// is rejected instead of decoding it as Nil
+func fromStringNonEmpty(val string) (K, error) {
+	if val == "" {
+		return K{}, fmt.Errorf("malformed k-order number: empty value")
+	}
+	return FromString(val)
+}
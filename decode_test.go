@@ -0,0 +1,97 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestDecodeAll(t *testing.T) {
+	g := guid.G(guid.Clock)
+	l := guid.L(guid.Clock)
+
+	lb, _ := l.MarshalJSON()
+	ltxt := strings.Trim(string(lb), `"`)
+
+	t.Run("Lines", func(t *testing.T) {
+		seq, err := guid.DecodeAll([]string{g.String(), ltxt})
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Seq(seq).Equal(g, l),
+		)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		seq, err := guid.DecodeAll([]string{g.String(), "foo", g.String()})
+
+		var e *guid.DecodeError
+		it.Then(t).Should(
+			it.True(errors.As(err, &e)),
+			it.Equal(e.Index, 1),
+			it.Seq(seq).Equal(g),
+		)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := guid.DecodeAll([]string{g.String(), ""})
+		_, errb := guid.DecodeAllBytes([]byte(g.String()+"\n"+"                \n"), 17)
+
+		var e *guid.DecodeError
+		it.Then(t).Should(
+			it.True(errors.As(err, &e)),
+			it.Equal(e.Index, 1),
+			it.True(errors.As(errb, &e)),
+			it.Equal(e.Index, 1),
+		)
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		b := append(guid.Bytes(g), guid.Bytes(g)...)
+		seq, err := guid.DecodeAllBytes(b, 12)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Seq(seq).Equal(g, g),
+		)
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		b := []byte(g.String() + " \n" + ltxt + strings.Repeat(" ", 17-len(ltxt)) + "\n")
+		seq, err := guid.DecodeAllBytes(b, 18)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Seq(seq).Equal(g, l),
+		)
+	})
+
+	t.Run("Stride", func(t *testing.T) {
+		_, err := guid.DecodeAllBytes(make([]byte, 13), 12)
+
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	})
+}
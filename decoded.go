@@ -0,0 +1,80 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"time"
+)
+
+// Decoded is read-only view of identifier fractions, all decoded at once.
+type Decoded struct {
+	T      time.Time
+	Node   uint32
+	Seq    uint16
+	Drift  time.Duration
+	Global bool
+}
+
+// Decode identifier into fractions, the drift bits are decoded only once.
+func Decode(uid K) Decoded {
+	d := driftOf(uid)
+
+	if uid.Hi == 0 {
+		return Decoded{
+			T:     time.Unix(0, int64(timeL(uid))),
+			Seq:   uint16(Seq(uid)),
+			Drift: driftStepOf(d),
+		}
+	}
+
+	return Decoded{
+		T:      time.Unix(0, int64(timeOfG(uid, d))),
+		Node:   uint32(nodeOfG(uid, d)),
+		Seq:    uint16(Seq(uid)),
+		Drift:  driftStepOf(d),
+		Global: true,
+	}
+}
+
+// K composes identifier back from fractions. The zero drift is the default one.
+func (v Decoded) K() (K, error) {
+	var drift []time.Duration
+	if v.Drift != 0 {
+		drift = []time.Duration{v.Drift}
+	}
+
+	if v.Global {
+		return Compose(v.T, v.Node, v.Seq, drift...)
+	}
+
+	if v.Node != 0 {
+		return K{}, fmt.Errorf("local identifier has node %d", v.Node)
+	}
+
+	if v.T.UnixNano() < 0 {
+		return K{}, fmt.Errorf("timestamp %s is before unix epoch", v.T)
+	}
+
+	if v.Seq > 0x3fff {
+		return K{}, fmt.Errorf("sequence %d exceeds 14 bits", v.Seq)
+	}
+
+	return makeL(driftInBits(drift), uint64(v.T.UnixNano()), uint64(v.Seq)), nil
+}
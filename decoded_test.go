@@ -0,0 +1,75 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestDecode(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	uid, _ := guid.Compose(at, 0xa, 5, 5*time.Minute)
+
+	t.Run("G", func(t *testing.T) {
+		v := guid.Decode(uid)
+		it.Then(t).Should(
+			it.True(v.Global),
+			it.Equal(v.T.UnixNano(), int64(guid.Time(uid))),
+			it.Equal(v.Node, 0xa),
+			it.Equal(v.Seq, 5),
+			it.Equal(v.Drift, 549*time.Second),
+		)
+
+		x, err := v.K()
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(x, uid),
+		)
+	})
+
+	t.Run("L", func(t *testing.T) {
+		l := guid.ToL(uid)
+		v := guid.Decode(l)
+		it.Then(t).Should(
+			it.True(!v.Global),
+			it.Equal(v.T.UnixNano(), int64(guid.Time(l))),
+			it.Equal(v.Node, 0),
+			it.Equal(v.Seq, 5),
+			it.Equal(v.Drift, 549*time.Second),
+		)
+
+		x, err := v.K()
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(x, l),
+		)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := guid.Decoded{T: at, Node: 1}.K()
+		it.Then(t).ShouldNot(it.Nil(err))
+
+		_, err = guid.Decoded{T: at, Seq: 0x4000, Global: true}.K()
+		it.Then(t).ShouldNot(it.Nil(err))
+	})
+}
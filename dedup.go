@@ -0,0 +1,100 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Deduper tracks recently seen identifiers within time-bounded window. The
+// eviction is driven by ⟨𝒕⟩ timestamp embedded into identifiers, the window
+// is relative to the latest timestamp observed by deduper.
+type Deduper struct {
+	mu       sync.Mutex
+	window   uint64
+	capacity int
+	latest   uint64
+	seen     map[K]struct{}
+	queue    byTime
+}
+
+// NewDeduper creates deduper with time window, it keeps at most capacity
+// identifiers, the oldest are evicted first. The capacity must be positive.
+func NewDeduper(window time.Duration, capacity int) *Deduper {
+	if capacity <= 0 {
+		panic("guid: deduper capacity must be positive")
+	}
+
+	return &Deduper{
+		window:   uint64(window),
+		capacity: capacity,
+		seen:     make(map[K]struct{}, capacity),
+		queue:    make(byTime, 0, capacity),
+	}
+}
+
+// Seen returns true if the identifier has been seen within the window,
+// otherwise it records the identifier. Identifiers older than the window
+// are never reported as seen.
+func (dd *Deduper) Seen(uid K) bool {
+	dd.mu.Lock()
+	defer dd.mu.Unlock()
+
+	if _, has := dd.seen[uid]; has {
+		return true
+	}
+
+	t := Time(uid)
+	if t > dd.latest {
+		dd.latest = t
+	}
+
+	if t+dd.window < dd.latest {
+		return false
+	}
+
+	dd.seen[uid] = struct{}{}
+	heap.Push(&dd.queue, uid)
+
+	for len(dd.queue) > 0 && (len(dd.queue) > dd.capacity || Time(dd.queue[0])+dd.window < dd.latest) {
+		delete(dd.seen, heap.Pop(&dd.queue).(K))
+	}
+
+	return false
+}
+
+// min-heap of identifiers ordered by timestamp, ties are resolved by k-order
+type byTime []K
+
+func (h byTime) Len() int { return len(h) }
+func (h byTime) Less(i, j int) bool {
+	ti, tj := Time(h[i]), Time(h[j])
+	return ti < tj || (ti == tj && Before(h[i], h[j]))
+}
+func (h byTime) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *byTime) Push(x any)   { *h = append(*h, x.(K)) }
+func (h *byTime) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
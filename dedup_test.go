@@ -0,0 +1,64 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestDeduper(t *testing.T) {
+	at := time.Now()
+	c := guid.NewClock(guid.WithClock(func() uint64 { return uint64(at.UnixNano()) }))
+	dd := guid.NewDeduper(time.Minute, 3)
+
+	a := guid.G(c)
+	b := guid.G(c)
+	it.Then(t).Should(
+		it.True(!dd.Seen(a)),
+		it.True(!dd.Seen(b)),
+		it.True(dd.Seen(a)),
+		it.True(dd.Seen(b)),
+	)
+
+	t.Run("Capacity", func(t *testing.T) {
+		dd.Seen(guid.G(c))
+		dd.Seen(guid.G(c))
+
+		it.Then(t).ShouldNot(
+			it.True(dd.Seen(a)),
+		)
+	})
+
+	t.Run("Window", func(t *testing.T) {
+		x := guid.G(c)
+		dd.Seen(x)
+
+		at = at.Add(2 * time.Minute)
+		dd.Seen(guid.G(c))
+
+		it.Then(t).ShouldNot(
+			it.True(dd.Seen(x)),
+			it.True(dd.Seen(x)),
+		)
+	})
+}
@@ -0,0 +1,68 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Dissection is a structured breakdown of k-ordered value
+type Dissection struct {
+	Type      string          `json:"type"`
+	ID        json.RawMessage `json:"id"`
+	Time      time.Time       `json:"time"`
+	T         uint64          `json:"t"`
+	Node      uint64          `json:"node"`
+	Seq       uint64          `json:"seq"`
+	Drift     string          `json:"drift"`
+	DriftBits uint64          `json:"drift_bits"`
+}
+
+// Dissect decodes fractions of k-ordered value
+func Dissect(uid K) Dissection {
+	id, _ := uid.MarshalJSON()
+	kind := "G"
+	if uid.Hi == 0 {
+		kind = "L"
+	}
+
+	drift := driftOf(uid)
+	return Dissection{
+		Type:      kind,
+		ID:        id,
+		Time:      EpochT(uid).UTC(),
+		T:         Time(uid),
+		Node:      Node(uid),
+		Seq:       Seq(uid),
+		Drift:     driftStepOf(drift).String(),
+		DriftBits: drift - driftZ,
+	}
+}
+
+// DissectorJSON produces structured JSON breakdown of binary k-ordered value
+// (8 or 12 bytes, see Bytes), intended for debug and network tooling.
+func DissectorJSON(b []byte) ([]byte, error) {
+	uid, err := FromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(Dissect(uid))
+}
@@ -0,0 +1,53 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestDissectorJSON(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	uid, _ := guid.Compose(at, 0xa, 5, 5*time.Minute)
+
+	b, err := guid.DissectorJSON(guid.Bytes(uid))
+	it.Then(t).Should(it.Nil(err))
+
+	var x guid.Dissection
+	err = json.Unmarshal(b, &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x.Type, "G"),
+		it.Equal(string(x.ID), `"`+uid.String()+`"`),
+		it.Equal(x.T, guid.Time(uid)),
+		it.Equal(x.Time.UnixNano(), int64(guid.Time(uid))),
+		it.Equal(x.Node, 0xa),
+		it.Equal(x.Seq, 5),
+		it.Equal(x.Drift, "9m9s"),
+		it.Equal(x.DriftBits, 4),
+	)
+
+	_, err = guid.DissectorJSON([]byte{1, 2, 3})
+	it.Then(t).ShouldNot(it.Nil(err))
+}
@@ -0,0 +1,104 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"time"
+)
+
+// DriftSteps returns drift values supported by identity schema. Any drift
+// value given to the library is coerced to the nearest step above it.
+func DriftSteps() []time.Duration {
+	return append([]time.Duration(nil), driftSteps[:]...)
+}
+
+// DriftRoundingError reports that drift value is not exactly representable
+// by identity schema, Step is the nearest step above it (see ParseDriftStrict).
+type DriftRoundingError struct {
+	Drift, Step time.Duration
+}
+
+func (e *DriftRoundingError) Error() string {
+	return fmt.Sprintf("drift %s is rounded to %s", e.Drift, e.Step)
+}
+
+// ParseDrift parses drift value (e.g. "5m") and maps it to the drift step.
+// Values that are not exactly representable are rounded up to the nearest
+// step. Non-positive values and values beyond the largest step fail.
+func ParseDrift(s string) (time.Duration, error) {
+	drift, err := parseDrift(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return RecommendDrift([]time.Duration{drift}), nil
+}
+
+// ParseDriftStrict is same as ParseDrift but values that are not exactly
+// representable fail with *DriftRoundingError, which reports the step.
+func ParseDriftStrict(s string) (time.Duration, error) {
+	drift, err := parseDrift(s)
+	if err != nil {
+		return 0, err
+	}
+
+	step := RecommendDrift([]time.Duration{drift})
+	if step != drift {
+		return 0, &DriftRoundingError{Drift: drift, Step: step}
+	}
+
+	return step, nil
+}
+
+func parseDrift(s string) (time.Duration, error) {
+	drift, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if drift <= 0 || drift > driftSteps[len(driftSteps)-1] {
+		return 0, fmt.Errorf("drift %s is not representable, supported range is (0, %s]", drift, driftSteps[len(driftSteps)-1])
+	}
+
+	return drift, nil
+}
+
+// RecommendDrift maps measured inter-node clock skews to the smallest drift
+// value, which tolerates all of them. The result is one of drift steps
+// supported by identity schema. The largest step is returned if skews exceed it.
+func RecommendDrift(observedSkews []time.Duration) time.Duration {
+	var skew time.Duration
+	for _, x := range observedSkews {
+		if x < 0 {
+			x = -x
+		}
+		if x > skew {
+			skew = x
+		}
+	}
+
+	for _, step := range driftSteps {
+		if skew <= step {
+			return step
+		}
+	}
+
+	return driftSteps[len(driftSteps)-1]
+}
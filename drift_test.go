@@ -0,0 +1,105 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestRecommendDrift(t *testing.T) {
+	for _, tt := range []struct {
+		skews  []time.Duration
+		expect time.Duration
+	}{
+		{nil, 68 * time.Second},
+		{[]time.Duration{time.Second, -30 * time.Second}, 68 * time.Second},
+		{[]time.Duration{10 * time.Second, -100 * time.Second}, 137 * time.Second},
+		{[]time.Duration{274 * time.Second}, 274 * time.Second},
+		{[]time.Duration{20 * time.Minute}, 2199 * time.Second},
+		{[]time.Duration{10 * time.Hour}, 4398 * time.Second},
+	} {
+		it.Then(t).Should(
+			it.Equal(guid.RecommendDrift(tt.skews), tt.expect),
+		)
+	}
+}
+
+func TestDriftSteps(t *testing.T) {
+	steps := guid.DriftSteps()
+	it.Then(t).Should(
+		it.Equal(len(steps), 7),
+		it.Equal(steps[0], 68*time.Second),
+		it.Equal(steps[6], 4398*time.Second),
+	)
+
+	for i, step := range steps {
+		c := guid.NewClock(guid.WithNodeID(0xffffffff))
+		bytes := guid.Bytes(guid.G(c, step))
+		it.Then(t).Should(
+			it.Equal(bytes[0]>>5, byte(i+1)),
+		)
+	}
+}
+
+func TestParseDrift(t *testing.T) {
+	d, err := guid.ParseDrift("137s")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(d, 137*time.Second),
+	)
+
+	d, err = guid.ParseDrift("5m")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(d, 549*time.Second),
+	)
+
+	var e *guid.DriftRoundingError
+	for _, s := range []string{"", "abc", "0s", "-1m", "2h"} {
+		_, err := guid.ParseDrift(s)
+		_, errStrict := guid.ParseDriftStrict(s)
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+			it.True(errors.As(err, &e)),
+			it.Nil(errStrict),
+			it.True(errors.As(errStrict, &e)),
+		)
+	}
+}
+
+func TestParseDriftStrict(t *testing.T) {
+	d, err := guid.ParseDriftStrict("137s")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(d, 137*time.Second),
+	)
+
+	var e *guid.DriftRoundingError
+	_, err = guid.ParseDriftStrict("5m")
+	it.Then(t).Should(
+		it.True(errors.As(err, &e)),
+		it.Equal(e.Drift, 5*time.Minute),
+		it.Equal(e.Step, 549*time.Second),
+	)
+}
@@ -0,0 +1,53 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "unsafe"
+
+// size of arena chunk used by Encoder for strings
+const encoderArena = 4096
+
+// Encoder is reusable encoder of k-ordered values. It amortizes allocations
+// for callers encoding in tight loops, who cannot use AppendString-style API.
+// The encoder is not safe for concurrent use.
+type Encoder struct {
+	arena []byte
+	bytes [bytesInG]byte
+}
+
+// EncodeString returns lexicographically sortable string of k-ordered value
+// (see String). Strings are carved from the shared arena, one allocation
+// serves hundreds of strings.
+func (enc *Encoder) EncodeString(uid K) string {
+	if cap(enc.arena)-len(enc.arena) < 16 {
+		enc.arena = make([]byte, 0, encoderArena)
+	}
+
+	n := len(enc.arena)
+	enc.arena = appendString(enc.arena, uid)
+	str := enc.arena[n:len(enc.arena):len(enc.arena)]
+
+	return *(*string)(unsafe.Pointer(&str))
+}
+
+// EncodeBytes returns binary form of k-ordered value (see Bytes). The slice
+// refers to the encoder's buffer, it is valid until the next call.
+func (enc *Encoder) EncodeBytes(uid K) []byte {
+	return appendBytes(enc.bytes[:0], uid)
+}
@@ -0,0 +1,64 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestEncoder(t *testing.T) {
+	var enc guid.Encoder
+
+	ids := make([]guid.K, 1000)
+	strs := make([]string, len(ids))
+	for i := range ids {
+		ids[i] = guid.G(guid.Clock)
+		strs[i] = enc.EncodeString(ids[i])
+	}
+
+	for i, uid := range ids {
+		it.Then(t).Should(
+			it.Equal(strs[i], guid.String(uid)),
+			it.Seq(enc.EncodeBytes(uid)).Equal(guid.Bytes(uid)...),
+		)
+	}
+
+	l := guid.ToL(ids[0])
+	it.Then(t).Should(
+		it.Equal(enc.EncodeString(l), guid.String(l)),
+		it.Seq(enc.EncodeBytes(l)).Equal(guid.Bytes(l)...),
+	)
+}
+
+func TestEncoderAllocs(t *testing.T) {
+	var enc guid.Encoder
+	uid := guid.G(guid.Clock)
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		enc.EncodeString(uid)
+		enc.EncodeBytes(uid)
+	})
+
+	it.Then(t).Should(
+		it.True(allocs < 0.1),
+	)
+}
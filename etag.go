@@ -0,0 +1,67 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ETag formats k-ordered value as strong entity tag, e.g. "NljBVm51PwMrZR.1".
+// Local values are encoded as canonical local string (see StringLocal).
+func ETag(uid K) string {
+	return `"` + stableString(uid) + `"`
+}
+
+// WeakETag formats k-ordered value as weak entity tag, e.g. W/"NljBVm51PwMrZR.1"
+func WeakETag(uid K) string {
+	return `W/"` + stableString(uid) + `"`
+}
+
+// ParseETag decodes k-ordered value from strong or weak entity tag, empty tag
+// is rejected
+func ParseETag(tag string) (K, error) {
+	val := strings.TrimPrefix(tag, "W/")
+	if len(val) < 2 || val[0] != '"' || val[len(val)-1] != '"' {
+		return K{}, fmt.Errorf("malformed entity tag: %v", tag)
+	}
+
+	return fromStringNonEmpty(val[1 : len(val)-1])
+}
+
+// MatchETag evaluates list of entity tags of If-Match (strong comparison)
+// or If-None-Match (weak comparison) header against k-ordered value.
+// Wildcard "*" matches any value. Malformed tags never match.
+func MatchETag(header string, uid K, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+
+		if !weak && strings.HasPrefix(tag, "W/") {
+			continue
+		}
+
+		if val, err := ParseETag(tag); err == nil && val == uid {
+			return true
+		}
+	}
+	return false
+}
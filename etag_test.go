@@ -0,0 +1,67 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestETag(t *testing.T) {
+	clock := guid.NewClock(guid.WithNodeID(0xa))
+
+	for _, uid := range []guid.K{guid.G(clock), guid.L(clock)} {
+		strong, errs := guid.ParseETag(guid.ETag(uid))
+		weak, errw := guid.ParseETag(guid.WeakETag(uid))
+
+		it.Then(t).Should(
+			it.Equal(guid.ETag(uid), `"`+guid.DeduplicationID(uid)+`"`),
+			it.Equal(guid.WeakETag(uid), `W/"`+guid.DeduplicationID(uid)+`"`),
+			it.Nil(errs),
+			it.Equal(strong, uid),
+			it.Nil(errw),
+			it.Equal(weak, uid),
+		)
+	}
+
+	for _, tag := range []string{"", `"`, "NljBVm51PwMrZR.1", `W/NljBVm51PwMrZR.1`, `"!!!"`, `""`, `W/""`} {
+		_, err := guid.ParseETag(tag)
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	}
+}
+
+func TestMatchETag(t *testing.T) {
+	clock := guid.NewClock(guid.WithNodeID(0xa))
+	a, b := guid.G(clock), guid.G(clock)
+
+	it.Then(t).Should(
+		it.True(guid.MatchETag(guid.ETag(a), a, false)),
+		it.True(guid.MatchETag(guid.ETag(b)+", "+guid.ETag(a), a, false)),
+		it.True(guid.MatchETag("*", a, false)),
+		it.True(guid.MatchETag(guid.WeakETag(a), a, true)),
+	).ShouldNot(
+		it.True(guid.MatchETag(guid.ETag(b), a, false)),
+		it.True(guid.MatchETag(guid.WeakETag(a), a, false)),
+		it.True(guid.MatchETag(`"invalid"`, a, true)),
+	)
+}
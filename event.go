@@ -0,0 +1,45 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "time"
+
+// Event is an envelope of data stamped with k-ordered value. The time and
+// node fractions are decoded from the identifier.
+type Event[T any] struct {
+	ID   K         `json:"id"`
+	At   time.Time `json:"at"`
+	Node uint32    `json:"node"`
+	Data T         `json:"data"`
+}
+
+// NewEvent stamps data with globally unique k-ordered identifier
+func NewEvent[T any](clock Chronos, data T, drift ...time.Duration) Event[T] {
+	return EventOf(G(clock, drift...), data)
+}
+
+// EventOf wraps data with existing k-ordered identifier
+func EventOf[T any](uid K, data T) Event[T] {
+	return Event[T]{
+		ID:   uid,
+		At:   EpochT(uid).UTC(),
+		Node: uint32(Node(uid)),
+		Data: data,
+	}
+}
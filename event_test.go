@@ -0,0 +1,54 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestEvent(t *testing.T) {
+	type Note struct {
+		Text string `json:"text"`
+	}
+
+	evt := guid.NewEvent(guid.NewClock(guid.WithNodeID(0xa)), Note{Text: "hello"})
+
+	it.Then(t).Should(
+		it.Equal(evt.Node, 0xa),
+		it.Equal(evt.At.UnixNano(), int64(guid.Time(evt.ID))),
+		it.Equal(evt.Data.Text, "hello"),
+	)
+
+	b, err := json.Marshal(evt)
+	it.Then(t).Should(it.Nil(err))
+
+	var x guid.Event[Note]
+	err = json.Unmarshal(b, &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x.ID, evt.ID),
+		it.True(x.At.Equal(evt.At)),
+		it.Equal(x.Node, evt.Node),
+		it.Equal(x.Data, evt.Data),
+	)
+}
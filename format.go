@@ -0,0 +1,116 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// FormatEncoder encodes k-ordered value to custom text form
+type FormatEncoder func(K) string
+
+// FormatDecoder decodes k-ordered value from custom text form
+type FormatDecoder func(string) (K, error)
+
+type format struct {
+	format Format
+	encode FormatEncoder
+	decode FormatDecoder
+}
+
+// registry of text encodings in the order of precedence
+var formats = struct {
+	sync.RWMutex
+	seq []format
+}{
+	seq: []format{
+		{FormatString, stableString, fromString64},
+		{FormatBase62, Base62, FromBase62},
+		{FormatHex, func(uid K) string { return hex.EncodeToString(Bytes(uid)) }, fromHex},
+		{FormatUUID, formatUUID, fromUUID},
+	},
+}
+
+// RegisterFormat associates custom text encoding with a name, so that
+// bespoke encodings of organization are recognized by ParseAny and command
+// line utility. The registration replaces the encoding previously associated
+// with the name, built-in encodings are not replaced.
+func RegisterFormat(name string, enc FormatEncoder, dec FormatDecoder) {
+	if name == "" || enc == nil || dec == nil {
+		panic("guid: format requires name, encoder and decoder")
+	}
+
+	formats.Lock()
+	defer formats.Unlock()
+
+	f := format{Format(name), enc, dec}
+	for i, x := range formats.seq {
+		if x.format == f.format {
+			if i < 4 {
+				panic("guid: unable to replace built-in format " + name)
+			}
+			formats.seq = slices.Clone(formats.seq)
+			formats.seq[i] = f
+			return
+		}
+	}
+
+	formats.seq = append(slices.Clip(formats.seq), f)
+}
+
+// Formats returns names of all text encodings in the order of precedence
+func Formats() []Format {
+	seq := registeredFormats()
+	names := make([]Format, len(seq))
+	for i, f := range seq {
+		names[i] = f.format
+	}
+	return names
+}
+
+// EncodeFormat encodes k-ordered value using named text encoding
+func EncodeFormat(uid K, name Format) (string, error) {
+	for _, f := range registeredFormats() {
+		if f.format == name {
+			return f.encode(uid), nil
+		}
+	}
+	return "", fmt.Errorf("guid: unknown format %s", name)
+}
+
+// DecodeFormat decodes k-ordered value using named text encoding
+func DecodeFormat(s string, name Format) (K, error) {
+	for _, f := range registeredFormats() {
+		if f.format == name {
+			return f.decode(s)
+		}
+	}
+	return K{}, fmt.Errorf("guid: unknown format %s", name)
+}
+
+// snapshot of registry, the sequence is never modified in place
+func registeredFormats() []format {
+	formats.RLock()
+	defer formats.RUnlock()
+
+	return formats.seq
+}
@@ -0,0 +1,76 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestFormats(t *testing.T) {
+	uid := guid.G(guid.NewClock())
+
+	for _, f := range guid.Formats() {
+		s, err := guid.EncodeFormat(uid, f)
+		it.Then(t).Should(it.Nil(err))
+
+		x, err := guid.DecodeFormat(s, f)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(x, uid),
+		)
+	}
+
+	_, err := guid.EncodeFormat(uid, "unknown")
+	it.Then(t).ShouldNot(
+		it.Nil(err),
+	)
+}
+
+func TestRegisterFormat(t *testing.T) {
+	guid.RegisterFormat("test.ord",
+		func(uid guid.K) string { return "ord_" + hex.EncodeToString(guid.Bytes(uid)) },
+		func(s string) (guid.K, error) {
+			b, err := hex.DecodeString(strings.TrimPrefix(s, "ord_"))
+			if err != nil {
+				return guid.K{}, err
+			}
+			return guid.FromBytes(b)
+		},
+	)
+
+	uid := guid.G(guid.NewClock())
+	s, err := guid.EncodeFormat(uid, "test.ord")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.True(strings.HasPrefix(s, "ord_")),
+		it.Seq(guid.Formats()).Contain(guid.Format("test.ord")),
+	)
+
+	x, f, err := guid.ParseAny(s)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(f, "test.ord"),
+		it.Equal(x, uid),
+	)
+}
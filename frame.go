@@ -0,0 +1,86 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// MediaTypeFrame is media type of binary frame of k-ordered values
+const MediaTypeFrame = "application/vnd.guid.k"
+
+const (
+	frameVersion = 1
+	frameHeader  = 1 + 4 // version, count
+	frameCRC     = 4
+)
+
+// MarshalFrame encodes list of k-ordered values into binary frame:
+//
+//	version (1 byte) ‖ count (4 bytes) ‖ count × 12 bytes ‖ CRC-32 (4 bytes)
+//
+// Integers are big-endian, records are binary form of global values, local
+// values are prefixed with 4 zero bytes, delete-markers are not carried (see
+// TombstoneOf). CRC-32 (IEEE) covers the whole frame.
+func MarshalFrame(ids []K) []byte {
+	b := make([]byte, frameHeader, frameHeader+len(ids)*bytesInG+frameCRC)
+	b[0] = frameVersion
+	binary.BigEndian.PutUint32(b[1:], uint32(len(ids)))
+
+	for _, uid := range ids {
+		b = binary.BigEndian.AppendUint32(b, uint32(uid.Hi))
+		b = binary.BigEndian.AppendUint64(b, uid.Lo)
+	}
+
+	return binary.BigEndian.AppendUint32(b, crc32.ChecksumIEEE(b))
+}
+
+// UnmarshalFrame decodes list of k-ordered values from binary frame
+func UnmarshalFrame(b []byte) ([]K, error) {
+	if len(b) < frameHeader+frameCRC {
+		return nil, fmt.Errorf("malformed k-order frame: %d bytes", len(b))
+	}
+
+	if b[0] != frameVersion {
+		return nil, fmt.Errorf("unsupported k-order frame version: %d", b[0])
+	}
+
+	n := int(binary.BigEndian.Uint32(b[1:]))
+	if len(b) != frameHeader+n*bytesInG+frameCRC {
+		return nil, fmt.Errorf("malformed k-order frame: %d bytes for %d records", len(b), n)
+	}
+
+	body, crc := b[:len(b)-frameCRC], binary.BigEndian.Uint32(b[len(b)-frameCRC:])
+	if crc32.ChecksumIEEE(body) != crc {
+		return nil, fmt.Errorf("malformed k-order frame: checksum mismatch")
+	}
+
+	ids := make([]K, n)
+	for i := range ids {
+		rec := body[frameHeader+i*bytesInG:]
+		ids[i] = K{
+			Hi: uint64(binary.BigEndian.Uint32(rec)),
+			Lo: binary.BigEndian.Uint64(rec[4:]),
+		}
+	}
+
+	return ids, nil
+}
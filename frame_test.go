@@ -0,0 +1,52 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestFrame(t *testing.T) {
+	ids := []guid.K{guid.G(guid.Clock), guid.L(guid.Clock), guid.Nil()}
+
+	b := guid.MarshalFrame(ids)
+	x, err := guid.UnmarshalFrame(b)
+
+	it.Then(t).Should(
+		it.Equal(len(b), 1+4+3*12+4),
+		it.Nil(err),
+		it.Seq(x).Equal(ids...),
+	)
+
+	empty, err := guid.UnmarshalFrame(guid.MarshalFrame(nil))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(empty), 0),
+	)
+
+	b[10] ^= 1
+	_, err = guid.UnmarshalFrame(b)
+	it.Then(t).ShouldNot(it.Nil(err))
+
+	_, err = guid.UnmarshalFrame(b[:20])
+	it.Then(t).ShouldNot(it.Nil(err))
+}
@@ -1,5 +1,5 @@
 module github.com/fogfish/guid/v2
 
-go 1.20
+go 1.23
 
 require github.com/fogfish/it/v2 v2.0.1
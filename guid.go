@@ -19,8 +19,12 @@
 package guid
 
 import (
+	"cmp"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"math/bits"
 	"time"
 	"unsafe"
 )
@@ -41,27 +45,39 @@ func (uid *K) UnmarshalJSON(b []byte) (err error) {
 		return
 	}
 
-	if val[0] == '*' {
-		*uid, err = FromStringG(val[1:])
-		if err != nil {
-			return err
-		}
-
-		*uid = ToL(*uid)
-		return nil
-	}
-
-	*uid, err = FromStringG(val)
+	*uid, err = FromString(val)
 	return err
 }
 
 // MarshalJSON encodes k-ordered value to lexicographically sortable JSON strings
 func (uid K) MarshalJSON() (bytes []byte, err error) {
-	if uid.Hi == 0 {
-		return json.Marshal("*" + String(FromL(Clock, uid)))
-	}
+	return appendJSON(make([]byte, 0, 19), uid), nil
+}
+
+// appendJSON appends quoted formatString, the alphabet requires no escaping
+func appendJSON(dst []byte, uid K) []byte {
+	dst = append(dst, '"')
+	dst = appendFormat(dst, uid)
+	return append(dst, '"')
+}
+
+// MarshalText encodes k-ordered value to text, same as JSON string. It makes
+// K usable as map key with encoding/json.
+func (uid K) MarshalText() ([]byte, error) {
+	b := appendJSON(make([]byte, 0, 19), uid)
+	return b[1 : len(b)-1], nil
+}
 
-	return json.Marshal(String(uid))
+// UnmarshalText decodes k-ordered value from text (see FromString)
+func (uid *K) UnmarshalText(b []byte) (err error) {
+	*uid, err = FromString(string(b))
+	return err
+}
+
+// formatString is inverse to FromString
+func formatString(uid K) string {
+	var buf [17]byte
+	return string(appendFormat(buf[:0], uid))
 }
 
 // String encoding of K-Order value
@@ -69,6 +85,15 @@ func (uid K) String() string {
 	return String(uid)
 }
 
+// LogValue implements slog.LogValuer, it emits lexicographically sortable
+// string and decoded ⟨𝒕⟩ timestamp for structured logs.
+func (uid K) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", String(uid)),
+		slog.Time("t", EpochT(uid)),
+	)
+}
+
 const (
 	bitsDrift    = 3
 	bitsSeq      = 14
@@ -89,8 +114,8 @@ func Z(clock Chronos, drift ...time.Duration) (uid K) {
 //	|-|-------------------|----------------|-----|-------|
 //	⟨𝒅⟩        ⟨𝒕⟩                ⟨𝒍⟩         ⟨𝒕⟩     ⟨𝒔⟩
 func G(clock Chronos, drift ...time.Duration) K {
-	t, seq := clock.T()
-	return makeG(clock.L(), driftInBits(drift), t, seq)
+	t, l, seq, d := observeWithDrift(clock, drift)
+	return makeG(l, d, t, seq)
 }
 
 func makeG(n, drift, t, seq uint64) (uid K) {
@@ -111,8 +136,8 @@ func makeG(n, drift, t, seq uint64) (uid K) {
 // ⟨𝒅⟩           ⟨𝒕⟩              ⟨𝒔⟩
 
 func L(clock Chronos, drift ...time.Duration) K {
-	t, seq := clock.T()
-	return makeL(driftInBits(drift), t, seq)
+	t, _, seq, d := observeWithDrift(clock, drift)
+	return makeL(d, t, seq)
 }
 
 func makeL(drift, t, seq uint64) (uid K) {
@@ -130,14 +155,51 @@ func Equal(a, b K) bool {
 	return a.Hi == b.Hi && a.Lo == b.Lo
 }
 
-// Before checks if k-ordered value A is before value B
+// EqualConstantTime compares k-order UIDs in constant time. Use it when
+// identifiers are secrets (e.g. bearer tokens or idempotency keys).
+func EqualConstantTime(a, b K) bool {
+	x := (a.Hi ^ b.Hi) | (a.Lo ^ b.Lo)
+	return subtle.ConstantTimeEq(int32(uint32(x|x>>32)), 0) == 1
+}
+
+// SameEvent checks if k-ordered values denote the same event modulo ⟨𝒍⟩
+// location, it compares ⟨𝒕⟩, ⟨𝒔⟩ and drift fractions only. It matches
+// values across local and global forms (e.g. FromL(clock, ToL(a))).
+func SameEvent(a, b K) bool {
+	return Time(a) == Time(b) && Seq(a) == Seq(b) && driftOf(a) == driftOf(b)
+}
+
+// Before checks if k-ordered value A is before value B.
+//
+// The comparison is raw binary, local (64-bit) value is always before any
+// global one. Global values are ordered by ⟨𝒕⟩ only within drift interval
+// of the same drift, ⟨𝒍⟩ takes priority otherwise. Use CompareMixed for
+// datasets that mix local and global values.
 func Before(a, b K) bool {
-	return (a.Hi < b.Hi) || (a.Hi == b.Hi && a.Lo < b.Lo)
+	// delete-marker follows the original value (see TombstoneOf)
+	ah, bh := a.Hi&^tombstoneBit, b.Hi&^tombstoneBit
+	return (ah < bh) || (ah == bh && (a.Lo < b.Lo || (a.Lo == b.Lo && a.Hi < b.Hi)))
 }
 
 // After checks if k-ordered value A is after value B
 func After(a, b K) bool {
-	return (a.Hi > b.Hi) || (a.Hi == b.Hi && a.Lo > b.Lo)
+	return Before(b, a)
+}
+
+// CompareMixed compares k-ordered values by decoded ⟨𝒕⟩ timestamp and ⟨𝒔⟩
+// sequence regardless of the form (local or global) and drift. The ⟨𝒍⟩
+// location breaks ties, local values have zero location. It returns -1 if
+// a is before b, +1 if a is after b, and 0 otherwise.
+func CompareMixed(a, b K) int {
+	if c := cmp.Compare(Time(a), Time(b)); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(Seq(a), Seq(b)); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(Node(a), Node(b))
 }
 
 // Time returns ⟨𝒕⟩ timestamp fraction from identifier in nano seconds
@@ -150,21 +212,22 @@ func Time(uid K) uint64 {
 }
 
 func timeG(uid K) uint64 {
+	return timeOfG(uid, (uid.Hi>>29)&7+driftZ)
+}
+
+func timeOfG(uid K, d uint64) uint64 {
 	//
 	//   3    47 - drift             32bit      drift   14
 	//  |-|-------------------|--------!-------|-----|-------|
 	//  ^                         b    ^   a                 ^
 	// 96                             64                     0
 	//
-	d := (uid.Hi >> 29) + driftZ
-	a := 64 - bitsSeq - d
-	b := 32 - a
-
-	hi := (uid.Hi >> b) << d
-	lo := (uid.Lo << a) >> (64 - d)
+	// shifts are masked by 63, which let compiler omit checks of large shifts
+	s := &layouts[(d-driftZ)&7]
+	hi := (uid.Hi & 0x1fffffff) >> (s.thi & 63)
+	lo := (uid.Lo >> bitsSeq) & s.tlo
 
-	t := ((hi | lo) << bitsSeqDrift)
-	return t
+	return (hi<<(s.d&63) | lo) << bitsSeqDrift
 }
 
 func timeL(uid K) uint64 {
@@ -172,8 +235,12 @@ func timeL(uid K) uint64 {
 }
 
 // EpochT convers ⟨𝒕⟩ timestamp fraction from identifier as unix timestamp
-func EpochT(uid K) time.Time {
-	return time.Unix(0, int64(Time(uid)))
+func EpochT(uid K, opts ...EpochOption) time.Time {
+	t := time.Unix(0, int64(Time(uid)))
+	for _, opt := range opts {
+		t = opt(t)
+	}
+	return t
 }
 
 // EpochI (inverse) convers ⟨𝒕⟩ timestamp fraction from identifier as unix timestamp
@@ -188,20 +255,18 @@ func Node(uid K) uint64 {
 		return 0
 	}
 
+	return nodeOfG(uid, (uid.Hi>>29)&7+driftZ)
+}
+
+func nodeOfG(uid K, d uint64) uint64 {
 	//
 	//   3    47 - drift             32bit      drift   14
 	//  |-|-------------------|--------!-------|-----|-------|
 	//  ^                         b    ^   a                 ^
 	// 96                             64                     0
 	//
-	d := (uid.Hi >> 29) + driftZ
-	a := 64 - bitsSeq - d
-	b := 32 - a
-
-	lo := uid.Lo >> (d + bitsSeq)
-	hi := uid.Hi << (64 - b) >> (64 - b - a)
-
-	return hi | lo
+	s := &layouts[(d-driftZ)&7]
+	return (uid.Lo>>(s.nlo&63) | uid.Hi<<(s.nhi&63)) & 0xffffffff
 }
 
 // Seq returns ⟨𝒔⟩ sequence value. The value of monotonic unique integer
@@ -216,7 +281,7 @@ func Diff(a, b K) K {
 	s := Seq(a) - Seq(b)
 
 	if a.Hi != 0 && b.Hi != 0 {
-		d := (a.Hi >> 29) + driftZ
+		d := (a.Hi>>29)&7 + driftZ
 		return makeG(Node(a), d, t, s)
 	}
 
@@ -224,6 +289,34 @@ func Diff(a, b K) K {
 	return makeL(d, t, s)
 }
 
+// Delta is a distance between k-ordered values, decoded from their fractions
+type Delta struct {
+	Duration time.Duration
+	SeqDelta int64
+	SameNode bool
+}
+
+// DiffReport measures distance between k-order UIDs a - b. Unlike Diff, it
+// decodes distance into time and sequence, which helps on debugging of
+// causality anomalies in event streams.
+func DiffReport(a, b K) Delta {
+	return Delta{
+		Duration: time.Duration(int64(Time(a) - Time(b))),
+		SeqDelta: int64(Seq(a)) - int64(Seq(b)),
+		SameNode: Node(a) == Node(b),
+	}
+}
+
+// String renders distance as "+3.2s/+17seq"
+func (d Delta) String() string {
+	sign := ""
+	if d.Duration >= 0 {
+		sign = "+"
+	}
+
+	return fmt.Sprintf("%s%s/%+dseq", sign, d.Duration, d.SeqDelta)
+}
+
 // Casts local (64-bit) k-order UID to global (96-bit) one
 func FromL(clock Chronos, uid K) K {
 	if uid.Hi != 0 {
@@ -240,7 +333,7 @@ func ToL(uid K) K {
 		return uid
 	}
 
-	d := (uid.Hi >> 29) + driftZ
+	d := (uid.Hi>>29)&7 + driftZ
 	return makeL(d, Time(uid), Seq(uid))
 }
 
@@ -249,6 +342,21 @@ func FromT(t time.Time, drift ...time.Duration) K {
 	return makeL(driftInBits(drift), uint64(t.UnixNano()), 0)
 }
 
+// Compose builds globally unique 96-bit k-ordered identifier from explicit
+// ⟨𝒕, 𝒍, 𝒔⟩ fractions. It helps test fixtures, backfill jobs and migrations to
+// mint precise historical identifiers without fake clocks.
+func Compose(t time.Time, node uint32, seq uint16, drift ...time.Duration) (K, error) {
+	if t.UnixNano() < 0 {
+		return K{}, fmt.Errorf("timestamp %s is before unix epoch", t)
+	}
+
+	if seq > 0x3fff {
+		return K{}, fmt.Errorf("sequence %d exceeds 14 bits", seq)
+	}
+
+	return makeG(uint64(node), driftInBits(drift), uint64(t.UnixNano()), uint64(seq)), nil
+}
+
 // Split decomposes UID value to bytes slice. The function acts as binary comprehension,
 // the value n defines number of bits to extract into each cell.
 func Split(n uint64, uid K) (bytes []byte) {
@@ -287,11 +395,15 @@ func Bytes(uid K) []byte {
 	}
 
 	var (
-		buf [12]byte
-		bfs = buf[:]
+		buf [13]byte
+		bfs = buf[:bytesInG]
 	)
 
 	split(uid.Hi, uid.Lo, 96, 8, bfs)
+	if uid.Hi&tombstoneBit != 0 {
+		// delete-marker is suffixed with zero byte (see TombstoneOf)
+		bfs = buf[:]
+	}
 	return bfs
 }
 
@@ -300,6 +412,14 @@ func FromBytes(val []byte) (K, error) {
 	switch len(val) {
 	case bytesInG:
 		return FoldG(8, val), nil
+	case bytesInG + 1:
+		if val[bytesInG] != 0 {
+			return K{}, fmt.Errorf("malformed k-order number: %v", val)
+		}
+
+		uid := FoldG(8, val[:bytesInG])
+		uid.Hi |= tombstoneBit
+		return uid, nil
 	case bytesInL:
 		return FoldL(8, val), nil
 	default:
@@ -309,42 +429,68 @@ func FromBytes(val []byte) (K, error) {
 
 // Encodes k-ordered value to lexicographically sortable base62 strings
 func Base62(uid K) string {
-	str := encode62(Bytes(uid))
+	var str []byte
+	if uid.Hi == 0 {
+		str = encode62(0, uid.Lo)
+	} else {
+		str = encode62(uid.Hi&0xffffffff, uid.Lo)
+	}
 	return *(*string)(unsafe.Pointer(&str))
 }
 
 // FromBase62 decodes converts k-order UID from base62 string
 func FromBase62(val string) (K, error) {
-	b, err := decode62([]byte(val))
+	hi, lo, err := decode62([]byte(val))
 	if err != nil {
 		return K{}, err
 	}
 
-	return FromBytes(b)
+	switch {
+	case bits.Len64(hi) > 24:
+		return K{Hi: hi, Lo: lo}, nil
+	case hi == 0 && bits.Len64(lo) > 56:
+		return K{Lo: lo}, nil
+	default:
+		return K{}, fmt.Errorf("malformed k-order number: %v", val)
+	}
 }
 
 // String encodes k-ordered value to lexicographically sortable strings
 func String(uid K) string {
-	var (
-		buf [16]byte // interim buffer where uid is split as seq of bytes
-		enc [16]byte // output encoded string
-		bfs = buf[:]
-	)
-
-	if uid.Hi == 0 {
-		split(0, uid.Lo, 64, 4, bfs)
-	} else {
-		split(uid.Hi, uid.Lo, 96, 6, bfs)
+	if uid.Hi&tombstoneBit != 0 {
+		return string(appendString(make([]byte, 0, 17), uid))
 	}
 
-	encode64(buf, &enc)
+	var enc [16]byte // output encoded string
+	encodeString(uid, &enc)
+
 	str := enc[:]
 	return *(*string)(unsafe.Pointer(&str))
 }
 
+func encodeString(uid K, enc *[16]byte) {
+	if uid.Hi == 0 {
+		// 16 cells of 4 bits
+		for i := uint64(0); i < 16; i++ {
+			enc[i] = alphabet[uid.Lo>>(60-4*i)&0xf]
+		}
+		return
+	}
+
+	// 16 cells of 6 bits, the cell 10 crosses hi | lo division
+	x := uid.Hi<<32 | uid.Lo>>32
+	for i := uint64(0); i < 10; i++ {
+		enc[i] = alphabet[x>>(58-6*i)&0x3f]
+	}
+	enc[10] = alphabet[(x&0xf)<<2|uid.Lo>>30&0x3]
+	for i := uint64(11); i < 16; i++ {
+		enc[i] = alphabet[uid.Lo>>(90-6*i)&0x3f]
+	}
+}
+
 // FromStringG decodes converts k-order UID from lexicographically sortable strings
 func FromStringG(val string) (K, error) {
-	if len(val) != 16 {
+	if len(val) != 16 || !valid64(val) {
 		return K{}, fmt.Errorf("malformed k-order number: %v", val)
 	}
 
@@ -353,9 +499,45 @@ func FromStringG(val string) (K, error) {
 
 // FromStringL decodes converts k-order UID from lexicographically sortable strings
 func FromStringL(val string) (K, error) {
-	if len(val) != 16 {
+	if len(val) != 16 || !valid64(val) {
 		return K{}, fmt.Errorf("malformed k-order number: %v", val)
 	}
 
 	return FoldL(4, decode64(val)), nil
 }
+
+// FromString decodes k-order UID from the lexicographically sortable string
+// produced by JSON encoding. It detects the form of value: 16 chars of global
+// one, 11 chars of canonical local one (see StringLocal), 17 chars of
+// delete-marker suffixed with '.' (see TombstoneOf) and legacy local value
+// promoted to global one, which is prefixed with '*' (see
+// SetPromoteOnMarshal). The empty string is Nil.
+func FromString(val string) (K, error) {
+	switch len(val) {
+	case 0:
+		return Nil(), nil
+	case charsInL:
+		return FromStringLocal(val)
+	}
+
+	switch {
+	case val[0] == '*':
+		uid, err := FromStringG(val[1:])
+		if err != nil {
+			return K{}, err
+		}
+
+		return ToL(uid), nil
+	case len(val) == 17 && val[16] == '.':
+		// delete-marker (see TombstoneOf)
+		uid, err := FromStringG(val[:16])
+		if err != nil {
+			return K{}, err
+		}
+
+		uid.Hi |= tombstoneBit
+		return uid, nil
+	}
+
+	return FromStringG(val)
+}
@@ -19,7 +19,11 @@
 package guid_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"log/slog"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -246,6 +250,32 @@ func TestDiffLZ(t *testing.T) {
 	}
 }
 
+func TestDiffReport(t *testing.T) {
+	ta := guid.NewClock(
+		guid.WithNodeID(0xa),
+		guid.WithClock(func() uint64 { return 10 * uint64(time.Second) }),
+		guid.WithUnique(func() uint64 { return 20 }),
+	)
+	tb := guid.NewClock(
+		guid.WithNodeID(0xa),
+		guid.WithClock(func() uint64 { return 13200 * uint64(time.Millisecond) }),
+		guid.WithUnique(func() uint64 { return 37 }),
+	)
+
+	a, b := guid.G(ta), guid.G(tb)
+	d := guid.DiffReport(b, a)
+
+	it.Then(t).Should(
+		it.True(d.SameNode),
+		it.Equal(d.SeqDelta, 17),
+		it.Equal(d.Duration.Round(100*time.Millisecond), 3200*time.Millisecond),
+		it.Equal(guid.DiffReport(a, b).SeqDelta, -17),
+		it.String(guid.DiffReport(b, a).String()).HavePrefix("+3.2"),
+		it.String(guid.DiffReport(b, a).String()).HaveSuffix("s/+17seq"),
+		it.String(guid.DiffReport(a, b).String()).HavePrefix("-3.2"),
+	)
+}
+
 func TestFromL(t *testing.T) {
 	for _, drift := range drifts {
 		c := guid.NewClock(
@@ -372,6 +402,31 @@ func TestFromT(t *testing.T) {
 	}
 }
 
+func TestCompose(t *testing.T) {
+	at := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, drift := range drifts[1:] {
+		a, err := guid.Compose(at, 0xfedcba98, 0x3fff, drift)
+		c := guid.NewClock(
+			guid.WithNodeID(0xfedcba98),
+			guid.WithClock(func() uint64 { return uint64(at.UnixNano()) }),
+			guid.WithUnique(func() uint64 { return 0x3fff }),
+		)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(a, guid.G(c, drift)),
+			it.Equal(guid.Node(a), 0xfedcba98),
+			it.Equal(guid.Seq(a), 0x3fff),
+		)
+	}
+
+	_, err := guid.Compose(at, 0, 0x4000)
+	it.Then(t).ShouldNot(it.Nil(err))
+
+	_, err = guid.Compose(time.Unix(-1, 0), 0, 0)
+	it.Then(t).ShouldNot(it.Nil(err))
+}
+
 func TestEpochT(t *testing.T) {
 	n := time.Now().Round(10 * time.Millisecond)
 	c := guid.NewClock(
@@ -494,6 +549,27 @@ func TestJSONCodec(t *testing.T) {
 	)
 }
 
+func TestMarshalJSON(t *testing.T) {
+	g := guid.G(guid.Clock)
+
+	for _, uid := range []guid.K{g, guid.ToL(g), guid.Nil()} {
+		b, err := uid.MarshalJSON()
+
+		var val string
+		json.Unmarshal(b, &val)
+		x, _ := guid.FromString(val)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(x, uid),
+		)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() { d, _ = g.MarshalJSON() })
+	it.Then(t).Should(
+		it.Equal(allocs, 1),
+	)
+}
+
 func TestJSONCodecFailed(t *testing.T) {
 	type MyStruct struct {
 		ID guid.K `json:"id"`
@@ -511,6 +587,159 @@ func TestJSONCodecFailed(t *testing.T) {
 	}
 }
 
+func TestLogValue(t *testing.T) {
+	c := guid.NewClock(guid.WithNodeID(0xffffffff))
+	a := guid.G(c)
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("event", "uid", a)
+
+	var x struct {
+		UID struct {
+			ID string    `json:"id"`
+			T  time.Time `json:"t"`
+		} `json:"uid"`
+	}
+	err := json.Unmarshal(buf.Bytes(), &x)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x.UID.ID, guid.String(a)),
+		it.Equal(x.UID.T.UnixNano(), guid.EpochT(a).UnixNano()),
+	)
+}
+
+func TestStringSplit(t *testing.T) {
+	const alphabet = ".0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+	encode := func(cells []byte) string {
+		b := make([]byte, len(cells))
+		for i, x := range cells {
+			b[i] = alphabet[x]
+		}
+		return string(b)
+	}
+
+	for _, uid := range []guid.K{
+		{},
+		{Hi: 0xffffffff, Lo: 0xffffffffffffffff},
+		{Hi: 0xa5a5a5a5, Lo: 0x5a5a5a5a5a5a5a5a},
+		{Lo: 0x0123456789abcdef},
+		guid.G(guid.Clock),
+		guid.L(guid.Clock),
+	} {
+		var expect string
+		if uid.Hi == 0 {
+			expect = encode(guid.Split(4, uid))
+		} else {
+			expect = encode(guid.Split(6, uid))
+		}
+
+		it.Then(t).Should(
+			it.Equal(guid.String(uid), expect),
+		)
+	}
+}
+
+func TestBase62Big(t *testing.T) {
+	// math/big uses 0-9a-zA-Z alphabet, the library uses 0-9A-Za-z
+	swap := func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return r
+	}
+
+	for _, uid := range []guid.K{
+		{Hi: 0xffffffff, Lo: 0xffffffffffffffff},
+		{Hi: 0x80000000, Lo: 0},
+		{Hi: 0xa5a5a5a5, Lo: 0x5a5a5a5a5a5a5a5a},
+		{Lo: 0xffffffffffffffff},
+		{Lo: 0x8123456789abcdef},
+		guid.G(guid.Clock),
+		guid.L(guid.Clock),
+	} {
+		expect := strings.Map(swap, new(big.Int).SetBytes(guid.Bytes(uid)).Text(62))
+		x, err := guid.FromBase62(expect)
+
+		it.Then(t).Should(
+			it.Equal(guid.Base62(uid), expect),
+			it.Nil(err),
+			it.Equal(x, uid),
+		)
+	}
+
+	for _, val := range []string{"", "zzzzzzzzzzzzzzzzz", "zzzzzz"} {
+		_, err := guid.FromBase62(val)
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	}
+}
+
+func TestFractionsOfDrift(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 34, 56, 789012345, time.UTC)
+	for _, drift := range []time.Duration{
+		time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute,
+		16 * time.Minute, 32 * time.Minute, time.Hour,
+	} {
+		uid, _ := guid.Compose(at, 0xfedcba98, 0x2bcd, drift)
+		it.Then(t).Should(
+			it.Equal(guid.Time(uid), uint64(at.UnixNano())>>17<<17),
+			it.Equal(guid.Node(uid), 0xfedcba98),
+			it.Equal(guid.Seq(uid), 0x2bcd),
+		)
+	}
+}
+
+func TestSameEvent(t *testing.T) {
+	a := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+	b := guid.FromL(guid.NewClock(guid.WithNodeID(0xb)), guid.ToL(a))
+
+	it.Then(t).Should(
+		it.True(guid.SameEvent(a, b)),
+		it.True(guid.SameEvent(a, guid.ToL(a))),
+	).ShouldNot(
+		it.Equal(a, b),
+		it.True(guid.SameEvent(a, guid.G(guid.Clock))),
+		it.True(guid.SameEvent(guid.FromT(time.Unix(1000, 0)), guid.FromT(time.Unix(1000, 0), 30*time.Minute))),
+	)
+}
+
+func TestEqualConstantTime(t *testing.T) {
+	a := guid.G(guid.Clock)
+	b := guid.G(guid.Clock)
+
+	it.Then(t).Should(
+		it.True(guid.EqualConstantTime(a, a)),
+		it.True(guid.EqualConstantTime(guid.ToL(a), guid.ToL(a))),
+		it.True(!guid.EqualConstantTime(a, b)),
+		it.True(!guid.EqualConstantTime(a, guid.ToL(a))),
+		it.True(!guid.EqualConstantTime(guid.K{Hi: 1 << 40}, guid.K{})),
+		it.True(!guid.EqualConstantTime(guid.K{Lo: 1}, guid.K{})),
+	)
+}
+
+func TestCompareMixed(t *testing.T) {
+	c := guid.NewClock(guid.WithNodeID(0xffffffff))
+	g := guid.G(c)
+	l := guid.L(c)
+	x := guid.G(c, 30*time.Minute)
+
+	// raw comparison puts local value first, regardless of the time
+	it.Then(t).Should(
+		it.True(guid.Before(l, g)),
+		it.Equal(guid.CompareMixed(g, l), -1),
+		it.Equal(guid.CompareMixed(l, g), 1),
+		it.Equal(guid.CompareMixed(l, x), -1),
+		it.Equal(guid.CompareMixed(g, g), 0),
+		it.Equal(guid.CompareMixed(guid.ToL(g), g), -1),
+	)
+}
+
 var (
 	k guid.K
 	s string
@@ -537,6 +766,43 @@ func BenchmarkGUID(b *testing.B) {
 		}
 	})
 
+	b.Run("Encode", func(b *testing.B) {
+		uid := guid.G(guid.Clock)
+		for i := 0; i < b.N; i++ {
+			s = guid.String(uid)
+		}
+	})
+
+	b.Run("Base62", func(b *testing.B) {
+		uid := guid.G(guid.Clock)
+		for i := 0; i < b.N; i++ {
+			s = guid.Base62(uid)
+		}
+	})
+
+	b.Run("FromBase62", func(b *testing.B) {
+		val := guid.Base62(guid.G(guid.Clock))
+		for i := 0; i < b.N; i++ {
+			k, _ = guid.FromBase62(val)
+		}
+	})
+
+	b.Run("MarshalJSON", func(b *testing.B) {
+		uid := guid.G(guid.Clock)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d, _ = uid.MarshalJSON()
+		}
+	})
+
+	b.Run("json.Marshal", func(b *testing.B) {
+		uid := guid.G(guid.Clock)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d, _ = json.Marshal(uid)
+		}
+	})
+
 	b.Run("Bytes", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			d = guid.Bytes(guid.G(guid.Clock))
@@ -556,3 +822,91 @@ func BenchmarkGUID(b *testing.B) {
 	})
 
 }
+
+func BenchmarkDecode(b *testing.B) {
+	drifts := []time.Duration{time.Minute, 5 * time.Minute, time.Hour}
+	ids := make([]guid.K, 1024)
+	for i := range ids {
+		ids[i] = guid.G(guid.Clock, drifts[i%len(drifts)])
+	}
+
+	b.Run("Time", func(b *testing.B) {
+		b.SetBytes(12)
+		for i := 0; i < b.N; i++ {
+			t = guid.Time(ids[i&1023])
+		}
+	})
+
+	b.Run("Node", func(b *testing.B) {
+		b.SetBytes(12)
+		for i := 0; i < b.N; i++ {
+			t = guid.Node(ids[i&1023])
+		}
+	})
+
+	b.Run("Decode", func(b *testing.B) {
+		b.SetBytes(12)
+		for i := 0; i < b.N; i++ {
+			t = uint64(guid.Decode(ids[i&1023]).Node)
+		}
+	})
+}
+
+func TestSentinel(t *testing.T) {
+	type MyStruct struct {
+		Parent guid.K `json:"parent"`
+		Status guid.K `json:"status"`
+	}
+
+	val := MyStruct{Parent: guid.Nil(), Status: guid.Tombstone()}
+	b, err := json.Marshal(val)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(b), `{"parent":"","status":"`+guid.Tombstone().String()+`"}`),
+	)
+
+	var x MyStruct
+	err = json.Unmarshal(b, &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x.Parent, guid.Nil()),
+		it.Equal(x.Status, guid.Tombstone()),
+	)
+
+	err = json.Unmarshal([]byte(`{"parent":null}`), &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x.Parent, guid.Nil()),
+	)
+
+	it.Then(t).Should(
+		it.Equal(guid.Node(guid.Tombstone()), 0),
+		it.Equal(guid.Seq(guid.Tombstone()), 0),
+		it.Equal(guid.Time(guid.Tombstone()), 0xffffffffffffffff>>17<<17),
+		it.True(guid.Before(guid.G(guid.NewClock(guid.WithNodeID(0))), guid.Tombstone())),
+	)
+}
+
+func TestOptional(t *testing.T) {
+	uid := guid.G(guid.Clock)
+
+	it.Then(t).Should(
+		it.True(guid.Nil().IsZero()),
+		it.True(guid.PtrOf(guid.Nil()) == nil),
+		it.Equal(*guid.PtrOf(uid), uid),
+		it.Equal(guid.ValueOr(guid.PtrOf(uid), guid.Tombstone()), uid),
+		it.Equal(guid.ValueOr(nil, guid.Tombstone()), guid.Tombstone()),
+	).ShouldNot(
+		it.True(uid.IsZero()),
+	)
+
+	type MyStruct struct {
+		Parent *guid.K `json:"parent,omitempty"`
+	}
+
+	b, err := json.Marshal(MyStruct{Parent: guid.PtrOf(guid.Nil())})
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(b), `{}`),
+	)
+}
@@ -0,0 +1,122 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guidtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// Fault transforms n-th observation ⟨𝒕, 𝒔⟩ of the clock. Faults are stateful,
+// each chaos clock requires own instances.
+type Fault func(n int, t, s uint64) (uint64, uint64)
+
+// ClockRegression steps the clock back by d at every n-th observation,
+// similarly to NTP correction. Regressions accumulate.
+func ClockRegression(every int, d time.Duration) Fault {
+	mustEvery(every)
+	return func(n int, t, s uint64) (uint64, uint64) {
+		return t - uint64(n/every)*uint64(d), s
+	}
+}
+
+// FrozenTicks freezes the clock for length observations at every n-th one.
+func FrozenTicks(every, length int) Fault {
+	mustEvery(every)
+
+	var frozen uint64
+	return func(n int, t, s uint64) (uint64, uint64) {
+		switch {
+		case n < every:
+			return t, s
+		case n%every == 0:
+			frozen = t
+		}
+
+		if n%every < length {
+			return frozen, s
+		}
+		return t, s
+	}
+}
+
+// SequenceStorm freezes the clock for length observations at every n-th one
+// and accelerates the sequence, so that it rolls over within the tick every
+// four observations.
+func SequenceStorm(every, length int) Fault {
+	mustEvery(every)
+
+	var frozen uint64
+	return func(n int, t, s uint64) (uint64, uint64) {
+		switch {
+		case n < every:
+			return t, s
+		case n%every == 0:
+			frozen = t
+		}
+
+		if i := n % every; i < length {
+			return frozen, (s + uint64(i)*0x1000) & 0x3fff
+		}
+		return t, s
+	}
+}
+
+func mustEvery(every int) {
+	if every <= 0 {
+		panic("guidtest: fault period must be positive")
+	}
+}
+
+// ChaosClock injects faults into observations of the base clock
+type ChaosClock struct {
+	base   guid.Observer
+	faults []Fault
+	mu     sync.Mutex
+	n      int
+}
+
+var _ guid.Observer = (*ChaosClock)(nil)
+
+// NewChaosClock creates clock that injects faults into observations of the
+// base clock, faults are applied in the given order.
+func NewChaosClock(base guid.Chronos, faults ...Fault) *ChaosClock {
+	return &ChaosClock{base: guid.AsObserver(base), faults: faults}
+}
+
+func (c *ChaosClock) L() uint64 { return c.base.L() }
+
+func (c *ChaosClock) T() (uint64, uint64) {
+	t, _, s := c.Now()
+	return t, s
+}
+
+func (c *ChaosClock) Now() (t, l, s uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, l, s = c.base.Now()
+	c.n++
+	for _, fault := range c.faults {
+		t, s = fault(c.n, t, s)
+	}
+	return t, l, s
+}
@@ -0,0 +1,86 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guidtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/guidtest"
+	"github.com/fogfish/it/v2"
+)
+
+// the clock ticks a millisecond per observation
+func ticking() guid.Chronos {
+	t := uint64(time.Hour)
+	return guid.NewClock(
+		guid.WithNodeID(0xa),
+		guid.WithClock(func() uint64 { t += uint64(time.Millisecond); return t }),
+	)
+}
+
+func TestClockRegression(t *testing.T) {
+	clock := guidtest.NewChaosClock(ticking(), guidtest.ClockRegression(4, time.Second))
+
+	ids := make([]guid.K, 8)
+	for i := range ids {
+		ids[i] = guid.G(clock)
+	}
+
+	it.Then(t).Should(
+		it.True(guid.Before(ids[1], ids[2])),
+		it.True(guid.After(ids[2], ids[3])),
+		it.True(guid.Time(ids[2])-guid.Time(ids[3]) > uint64(900*time.Millisecond)),
+		it.True(guid.Before(ids[3], ids[4])),
+		it.Equal(clock.L(), 0xa),
+	)
+}
+
+func TestFrozenTicks(t *testing.T) {
+	clock := guidtest.NewChaosClock(ticking(), guidtest.FrozenTicks(4, 3))
+
+	ticks := make([]uint64, 8)
+	for i := range ticks {
+		ticks[i], _ = clock.T()
+	}
+
+	it.Then(t).Should(
+		it.Equal(ticks[3], ticks[4]),
+		it.Equal(ticks[3], ticks[5]),
+		it.True(ticks[6] > ticks[5]),
+		it.True(ticks[2] > ticks[1]),
+	)
+}
+
+func TestSequenceStorm(t *testing.T) {
+	clock := guidtest.NewChaosClock(ticking(), guidtest.SequenceStorm(8, 6))
+
+	ids := make([]guid.K, 16)
+	for i := range ids {
+		ids[i] = guid.G(clock)
+	}
+
+	// sequence rolls over within the frozen tick
+	it.Then(t).Should(
+		it.Equal(guid.Time(ids[7]), guid.Time(ids[12])),
+		it.Equal(guid.Seq(ids[11]), (guid.Seq(ids[7])+4)&0x3fff),
+		it.True(guid.Time(ids[13]) > guid.Time(ids[12])),
+	)
+}
@@ -0,0 +1,115 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package guidtest provides utilities for testing applications that use
+// k-ordered identifiers.
+package guidtest
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// Epoch of synthetic streams, the virtual time starts at this instant
+var Epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SyntheticStream is reproducible stream of k-ordered values, allocated by
+// multiple virtual nodes. Nodes have jittered and skewed clocks, the skew
+// occasionally drifts. The stream is not safe for concurrent use.
+type SyntheticStream struct {
+	rnd   *rand.Rand
+	at    time.Time
+	step  time.Duration
+	nodes []synthetic
+}
+
+type synthetic struct {
+	id    uint32
+	seq   uint16
+	skew  time.Duration
+	drift time.Duration
+}
+
+// NewSyntheticStream creates stream of k-ordered values allocated by given
+// number of nodes at the rate of identifiers per second (virtual time).
+// The same seed produces the same stream.
+func NewSyntheticStream(seed int64, nodes int, rate int) *SyntheticStream {
+	if nodes <= 0 || rate <= 0 {
+		panic("guidtest: nodes and rate must be positive")
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	stream := &SyntheticStream{
+		rnd:   rnd,
+		at:    Epoch,
+		step:  time.Second / time.Duration(rate),
+		nodes: make([]synthetic, nodes),
+	}
+
+	for i := range stream.nodes {
+		node := &stream.nodes[i]
+		node.id = rnd.Uint32()
+		node.seq = uint16(rnd.Intn(0x4000))
+		node.skew = time.Duration(rnd.NormFloat64() * float64(500*time.Millisecond))
+		// one of eight nodes is configured with larger drift
+		if rnd.Intn(8) == 0 {
+			node.drift = 30 * time.Minute
+		} else {
+			node.drift = 5 * time.Minute
+		}
+	}
+
+	return stream
+}
+
+// Time returns virtual time of the stream
+func (stream *SyntheticStream) Time() time.Time { return stream.at }
+
+// Next returns next k-ordered value of the stream
+func (stream *SyntheticStream) Next() guid.K {
+	rnd := stream.rnd
+
+	// allocations are Poisson process at the rate of the stream
+	stream.at = stream.at.Add(time.Duration(rnd.ExpFloat64() * float64(stream.step)))
+
+	node := &stream.nodes[rnd.Intn(len(stream.nodes))]
+	// the skew of node clock occasionally drifts
+	if rnd.Intn(1000) == 0 {
+		node.skew += time.Duration(rnd.NormFloat64() * float64(time.Second))
+	}
+
+	jitter := time.Duration(rnd.Int63n(int64(100 * time.Microsecond)))
+	node.seq = (node.seq + 1) & 0x3fff
+
+	uid, err := guid.Compose(stream.at.Add(node.skew+jitter), node.id, node.seq, node.drift)
+	if err != nil {
+		panic(err)
+	}
+	return uid
+}
+
+// Take returns next n k-ordered values of the stream
+func (stream *SyntheticStream) Take(n int) []guid.K {
+	seq := make([]guid.K, n)
+	for i := range seq {
+		seq[i] = stream.Next()
+	}
+	return seq
+}
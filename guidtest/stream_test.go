@@ -0,0 +1,56 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guidtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/guidtest"
+	"github.com/fogfish/it/v2"
+)
+
+func TestSyntheticStream(t *testing.T) {
+	a := guidtest.NewSyntheticStream(42, 8, 1000).Take(10000)
+	b := guidtest.NewSyntheticStream(42, 8, 1000).Take(10000)
+	c := guidtest.NewSyntheticStream(43, 8, 1000).Take(10000)
+
+	nodes := map[uint64]struct{}{}
+	for _, uid := range a {
+		nodes[guid.Node(uid)] = struct{}{}
+	}
+
+	it.Then(t).Should(
+		it.Seq(a).Equal(b...),
+		it.Equal(len(nodes), 8),
+	).ShouldNot(
+		it.Equal(a[0], c[0]),
+	)
+}
+
+func TestSyntheticStreamRate(t *testing.T) {
+	stream := guidtest.NewSyntheticStream(42, 4, 1000)
+	stream.Take(10000)
+
+	elapsed := stream.Time().Sub(guidtest.Epoch)
+	it.Then(t).Should(
+		it.True(elapsed > 9*time.Second && elapsed < 11*time.Second),
+	)
+}
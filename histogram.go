@@ -0,0 +1,119 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Histogram is time-bucketed counter of k-ordered values per node location.
+// It uses ⟨𝒕⟩ and ⟨𝒍⟩ fractions embedded into identifiers to rebuild
+// "events per node per time interval" statistic from raw IDs.
+type Histogram struct {
+	mu     sync.Mutex
+	bucket uint64
+	counts map[bin]uint64
+}
+
+type bin struct{ node, at uint64 }
+
+// Bin is a counter of k-ordered values allocated by node within time bucket
+type Bin struct {
+	Node  uint64
+	At    time.Time
+	Count uint64
+}
+
+// NewHistogram creates histogram with given time bucket
+func NewHistogram(bucket time.Duration) *Histogram {
+	if bucket <= 0 {
+		bucket = time.Minute
+	}
+
+	return &Histogram{
+		bucket: uint64(bucket),
+		counts: make(map[bin]uint64),
+	}
+}
+
+// Add ingests k-ordered values into histogram
+func (h *Histogram) Add(uids ...K) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, uid := range uids {
+		h.counts[bin{node: Node(uid), at: Time(uid) / h.bucket}]++
+	}
+}
+
+// Bins returns counters ordered by time bucket and node
+func (h *Histogram) Bins() []Bin {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	seq := make([]Bin, 0, len(h.counts))
+	for k, n := range h.counts {
+		seq = append(seq, Bin{
+			Node:  k.node,
+			At:    time.Unix(0, int64(k.at*h.bucket)).UTC(),
+			Count: n,
+		})
+	}
+
+	sort.Slice(seq, func(i, j int) bool {
+		if seq[i].At.Equal(seq[j].At) {
+			return seq[i].Node < seq[j].Node
+		}
+		return seq[i].At.Before(seq[j].At)
+	})
+
+	return seq
+}
+
+// WriteTo exports histogram using Prometheus text exposition format.
+// Each bin is written as sample of guid_events metric labeled by node and
+// unix timestamp of time bucket.
+//
+//	guid_events{node="fedcba98",t="1717243380"} 17
+func (h *Histogram) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+
+	n, err := fmt.Fprint(w,
+		"# HELP guid_events Number of k-ordered identifiers allocated by node within time bucket.\n",
+		"# TYPE guid_events gauge\n",
+	)
+	total += int64(n)
+	if err != nil {
+		return total, err
+	}
+
+	for _, b := range h.Bins() {
+		n, err := fmt.Fprintf(w, "guid_events{node=\"%08x\",t=\"%d\"} %d\n", b.Node, b.At.Unix(), b.Count)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,59 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestHistogram(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 3, 0, 0, time.UTC)
+	ta := guid.NewClock(
+		guid.WithNodeID(0xa),
+		guid.WithClock(func() uint64 { return uint64(at.Add(10 * time.Second).UnixNano()) }),
+	)
+	tb := guid.NewClock(
+		guid.WithNodeID(0xb),
+		guid.WithClock(func() uint64 { return uint64(at.Add(70 * time.Second).UnixNano()) }),
+	)
+
+	h := guid.NewHistogram(time.Minute)
+	h.Add(guid.G(ta), guid.G(ta), guid.G(tb))
+
+	bins := h.Bins()
+	it.Then(t).Should(
+		it.Seq(bins).Equal(
+			guid.Bin{Node: 0xa, At: at, Count: 2},
+			guid.Bin{Node: 0xb, At: at.Add(time.Minute), Count: 1},
+		),
+	)
+
+	var buf bytes.Buffer
+	_, err := h.WriteTo(&buf)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(buf.String()).Contain(`guid_events{node="0000000a",t="1717243380"} 2`),
+		it.String(buf.String()).Contain(`guid_events{node="0000000b",t="1717243440"} 1`),
+	)
+}
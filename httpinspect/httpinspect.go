@@ -0,0 +1,85 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package httpinspect implements an HTTP service that decodes identifiers.
+//
+//	http.Handle("/inspect/", http.StripPrefix("/inspect/", httpinspect.Handler()))
+//
+// The service accepts identifier either as path or query parameter id,
+// and responds with JSON breakdown of fractions. The identifier is in any
+// supported text encoding: lexicographically sortable string, base62, hex of
+// binary form, UUID or encoding registered by guid.RegisterFormat. Encoding
+// is detected automatically (see guid.ParseAny), query parameter enc names
+// the encoding (e.g. enc=base62) and resolves ambiguity of short values.
+package httpinspect
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// Handler returns http.Handler of identifier inspection service
+func Handler() http.Handler {
+	return http.HandlerFunc(inspect)
+}
+
+func inspect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		failure(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		id = strings.Trim(r.URL.Path, "/")
+	}
+
+	uid, err := decode(id, r.URL.Query().Get("enc"))
+	if err != nil {
+		failure(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(guid.Dissect(uid))
+}
+
+// decode identifier from any supported encoding
+func decode(id, enc string) (guid.K, error) {
+	if id == "" {
+		return guid.K{}, errors.New("identifier is not defined")
+	}
+
+	if enc != "" {
+		return guid.DecodeFormat(id, guid.Format(enc))
+	}
+
+	uid, _, err := guid.ParseAny(id)
+	return uid, err
+}
+
+func failure(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,72 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package httpinspect_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/httpinspect"
+	"github.com/fogfish/it/v2"
+)
+
+func TestHandler(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+	uuid, _ := guid.EncodeFormat(uid, guid.FormatUUID)
+
+	for _, url := range []string{
+		"/" + uid.String(),
+		"/?id=" + uid.String(),
+		"/" + guid.Base62(uid),
+		"/" + guid.Base62(uid) + "?enc=base62",
+		"/" + hex.EncodeToString(guid.Bytes(uid)),
+		"/?id=" + hex.EncodeToString(guid.Bytes(uid)) + "&enc=hex",
+		"/" + uuid,
+	} {
+		w := httptest.NewRecorder()
+		httpinspect.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+		var x guid.Dissection
+		err := json.Unmarshal(w.Body.Bytes(), &x)
+
+		it.Then(t).Should(
+			it.Equal(w.Code, http.StatusOK),
+			it.Nil(err),
+			it.Equal(x.Node, 0xa),
+			it.Equal(x.T, guid.Time(uid)),
+		)
+	}
+
+	for url, code := range map[string]int{
+		"/":                             http.StatusBadRequest,
+		"/?id=!!!!":                     http.StatusBadRequest,
+		"/" + uid.String() + "?enc=foo": http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		httpinspect.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+		it.Then(t).Should(
+			it.Equal(w.Code, code),
+		)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IdempotencyKey mints value of Idempotency-Key HTTP header. The key is
+// lexicographically sortable string of global k-ordered value, which uses
+// HTTP token characters only.
+func IdempotencyKey(clock Chronos) string {
+	return String(G(clock))
+}
+
+// ParseIdempotencyKey recovers creation time of Idempotency-Key, so that
+// server expires keys. The key is accepted either as token or quoted string.
+func ParseIdempotencyKey(key string) (time.Time, error) {
+	val := strings.TrimSpace(key)
+	if len(val) >= 2 && val[0] == '"' && val[len(val)-1] == '"' {
+		val = val[1 : len(val)-1]
+	}
+
+	uid, err := FromStringG(val)
+	if err != nil || strings.Trim(val, alphabet) != "" {
+		return time.Time{}, fmt.Errorf("malformed idempotency key: %s", key)
+	}
+
+	return EpochT(uid), nil
+}
@@ -0,0 +1,47 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	at := time.Now()
+	key := guid.IdempotencyKey(guid.Clock)
+
+	for _, val := range []string{key, `"` + key + `"`} {
+		created, err := guid.ParseIdempotencyKey(val)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(created.Sub(at).Abs() < time.Second),
+		)
+	}
+
+	for _, val := range []string{"", "abc", "8e03978e-40d5-43e8"} {
+		_, err := guid.ParseIdempotencyKey(val)
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// size of journal record: 6 bytes of tick, 2 bytes of seq and 4 bytes of node
+const bytesInJournal = 12
+
+// journal of allocated identifiers. The lock serializes observations of the
+// clock with writes, records are appended in allocation order.
+type journal struct {
+	sync.Mutex
+	w   io.Writer
+	buf [bytesInJournal]byte
+	err error
+}
+
+// writes record, the caller holds the lock
+func (j *journal) write(t, l, s uint64) {
+	if j.err != nil {
+		return
+	}
+
+	tick := t >> bitsSeqDrift
+	binary.BigEndian.PutUint16(j.buf[0:2], uint16(tick>>32))
+	binary.BigEndian.PutUint32(j.buf[2:6], uint32(tick))
+	binary.BigEndian.PutUint16(j.buf[6:8], uint16(s&0x3fff))
+	binary.BigEndian.PutUint32(j.buf[8:12], uint32(l))
+	_, j.err = j.w.Write(j.buf[:])
+}
+
+// WithJournal appends a compact binary record ⟨𝒕, 𝒔, 𝒍⟩ to the writer for
+// every identifier allocated by G, L, GWait and sequence leases. Allocations
+// are serialized with writes, use buffered writer to reduce the overhead.
+// The journalling stops on the first write error, see JournalError.
+func WithJournal(w io.Writer) Config {
+	return func(clock *clock) {
+		clock.journal = &journal{w: w}
+	}
+}
+
+// JournalError returns the first error occurred while writing the journal
+// of the clock.
+func JournalError(origin Chronos) error {
+	c, ok := origin.(*clock)
+	if !ok || c.journal == nil {
+		return nil
+	}
+
+	c.journal.Lock()
+	defer c.journal.Unlock()
+	return c.journal.err
+}
+
+// JournalRecord is the allocation of identifier, ⟨𝒕⟩ is unix timestamp
+// truncated to the precision of identifier.
+type JournalRecord struct {
+	T    uint64
+	Seq  uint16
+	Node uint32
+}
+
+// JournalReader replays the journal of allocated identifiers
+type JournalReader struct {
+	r      io.Reader
+	buf    [bytesInJournal]byte
+	offset int64
+}
+
+// NewJournalReader creates reader of the journal
+func NewJournalReader(r io.Reader) *JournalReader {
+	return &JournalReader{r: r}
+}
+
+// Offset returns the byte offset of the next record
+func (r *JournalReader) Offset() int64 { return r.offset }
+
+// Read returns the next record of the journal, io.EOF at the end of journal.
+func (r *JournalReader) Read() (JournalRecord, error) {
+	switch n, err := io.ReadFull(r.r, r.buf[:]); {
+	case err == io.EOF:
+		return JournalRecord{}, io.EOF
+	case err == io.ErrUnexpectedEOF:
+		return JournalRecord{}, fmt.Errorf("truncated journal record at %d: %d bytes", r.offset, n)
+	case err != nil:
+		return JournalRecord{}, err
+	}
+
+	tick := uint64(binary.BigEndian.Uint16(r.buf[0:2]))<<32 | uint64(binary.BigEndian.Uint32(r.buf[2:6]))
+	rec := JournalRecord{
+		T:    tick << bitsSeqDrift,
+		Seq:  binary.BigEndian.Uint16(r.buf[6:8]),
+		Node: binary.BigEndian.Uint32(r.buf[8:12]),
+	}
+	r.offset += bytesInJournal
+	return rec, nil
+}
+
+// Anomalies reported by VerifyJournal
+var (
+	ErrJournalNonMonotonic = errors.New("guid: journal is not monotonic")
+	ErrJournalDuplicate    = errors.New("guid: journal has duplicate ⟨t, s⟩ pair")
+)
+
+// JournalAnomaly is the record of journal that violates monotonicity or
+// uniqueness of issued ⟨𝒕, 𝒔⟩ pairs.
+type JournalAnomaly struct {
+	Offset int64
+	Record JournalRecord
+	Err    error
+}
+
+func (e *JournalAnomaly) Error() string {
+	return fmt.Sprintf("%v at offset %d", e.Err, e.Offset)
+}
+
+func (e *JournalAnomaly) Unwrap() error { return e.Err }
+
+// VerifyJournal replays the journal and checks that issued ⟨𝒕, 𝒔⟩ pairs are
+// unique and strictly monotonic: ⟨𝒕⟩ never goes back and ⟨𝒔⟩ strictly follows
+// within the tick. The direction (ascending or inverse clock) is inferred
+// from the journal. All anomalies are joined into error as *JournalAnomaly.
+func VerifyJournal(r io.Reader) error {
+	type pair struct {
+		t   uint64
+		seq uint16
+	}
+
+	var (
+		errs []error
+		seen = map[pair]int64{}
+		prev pair
+		dir  int
+	)
+
+	jr := NewJournalReader(r)
+	for {
+		offset := jr.Offset()
+		rec, err := jr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		p := pair{rec.T, rec.Seq}
+		if at, has := seen[p]; has {
+			errs = append(errs, &JournalAnomaly{offset, rec, fmt.Errorf("%w (first at offset %d)", ErrJournalDuplicate, at)})
+			prev = p
+			continue
+		}
+		seen[p] = offset
+
+		if offset != 0 {
+			c := cmp.Compare(p.t, prev.t)
+			if c == 0 {
+				c = cmp.Compare(p.seq, prev.seq)
+			}
+			if dir == 0 {
+				dir = c
+			}
+			if c != dir {
+				errs = append(errs, &JournalAnomaly{offset, rec, ErrJournalNonMonotonic})
+			}
+		}
+		prev = p
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,122 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestJournal(t *testing.T) {
+	var buf bytes.Buffer
+	clock := guid.NewClock(guid.WithNodeID(0xabcdef), guid.WithJournal(&buf))
+
+	ids := []guid.K{guid.G(clock), guid.L(clock)}
+	lease := clock.Lease(4)
+	ids = append(ids, lease.G(), lease.L())
+
+	it.Then(t).Should(
+		it.Nil(guid.JournalError(clock)),
+		it.Equal(buf.Len(), 12*len(ids)),
+	)
+
+	r := guid.NewJournalReader(&buf)
+	for _, uid := range ids {
+		rec, err := r.Read()
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(rec.T, guid.Time(uid)),
+			it.Equal(uint64(rec.Seq), guid.Seq(uid)),
+			it.Equal(rec.Node, 0xabcdef),
+		)
+	}
+
+	_, err := r.Read()
+	it.Then(t).Should(
+		it.Equal(err, io.EOF),
+		it.Equal(r.Offset(), int64(12*len(ids))),
+	)
+}
+
+func TestJournalTruncated(t *testing.T) {
+	r := guid.NewJournalReader(bytes.NewReader(make([]byte, 13)))
+
+	_, err := r.Read()
+	it.Then(t).Should(it.Nil(err))
+
+	_, err = r.Read()
+	it.Then(t).ShouldNot(it.Nil(err))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestJournalError(t *testing.T) {
+	clock := guid.NewClock(guid.WithJournal(failingWriter{}))
+	guid.G(clock)
+
+	it.Then(t).ShouldNot(
+		it.Nil(guid.JournalError(clock)),
+	).Should(
+		it.Nil(guid.JournalError(guid.NewClock())),
+	)
+}
+
+func TestVerifyJournal(t *testing.T) {
+	for name, opt := range map[string]guid.Config{
+		"unix":    guid.WithClockUnix(),
+		"inverse": guid.WithClockInverse(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			clock := guid.NewClock(opt, guid.WithJournal(&buf))
+			for i := 0; i < 1000; i++ {
+				guid.G(clock)
+			}
+
+			it.Then(t).Should(
+				it.Nil(guid.VerifyJournal(&buf)),
+			)
+		})
+	}
+
+	t.Run("Anomalies", func(t *testing.T) {
+		var journal []byte
+		for _, x := range [][2]byte{{10, 1}, {10, 2}, {11, 0}, {10, 5}, {11, 0}} {
+			journal = append(journal, 0, 0, 0, 0, 0, x[0], 0, x[1], 0, 0, 0, 1)
+		}
+
+		err := guid.VerifyJournal(bytes.NewReader(journal))
+
+		var anomaly *guid.JournalAnomaly
+		it.Then(t).Should(
+			it.True(errors.Is(err, guid.ErrJournalDuplicate)),
+			it.True(errors.Is(err, guid.ErrJournalNonMonotonic)),
+			it.True(errors.As(err, &anomaly)),
+			it.Equal(anomaly.Offset, 36),
+			it.Equal(anomaly.Record.Seq, 5),
+		)
+	})
+}
@@ -0,0 +1,51 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/json/jsontext"
+	"fmt"
+)
+
+// MarshalJSONTo implements json.MarshalerTo of encoding/json/v2
+func (uid K) MarshalJSONTo(enc *jsontext.Encoder) error {
+	var buf [19]byte
+	return enc.WriteValue(appendJSON(buf[:0], uid))
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom of encoding/json/v2
+func (uid *K) UnmarshalJSONFrom(dec *jsontext.Decoder) (err error) {
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+
+	switch tok.Kind() {
+	case 'n':
+		*uid = Nil()
+		return nil
+	case '"':
+		*uid, err = FromString(tok.String())
+		return err
+	default:
+		return fmt.Errorf("malformed k-order number: %v", tok)
+	}
+}
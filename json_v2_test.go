@@ -0,0 +1,72 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/json/v2"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestJSONv2Codec(t *testing.T) {
+	type MyStruct struct {
+		G guid.K `json:"g"`
+		L guid.K `json:"l"`
+		N guid.K `json:"n"`
+	}
+
+	val := MyStruct{G: guid.G(guid.Clock), L: guid.L(guid.Clock)}
+	b, err := json.Marshal(val)
+	it.Then(t).Should(it.Nil(err))
+
+	var x MyStruct
+	err = json.Unmarshal(b, &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x, val),
+	).ShouldNot(
+		it.Nil(json.Unmarshal([]byte(`{"g":100}`), &x)),
+	)
+}
+
+func TestJSONv2OmitZero(t *testing.T) {
+	type MyStruct struct {
+		ID     guid.K `json:"id"`
+		Parent guid.K `json:"parent,omitzero"`
+	}
+
+	uid := guid.G(guid.Clock)
+	b, err := json.Marshal(MyStruct{ID: uid})
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(b), `{"id":"`+uid.String()+`"}`),
+	)
+}
+
+func BenchmarkJSONv2(b *testing.B) {
+	uid := guid.G(guid.Clock)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		json.Marshal(uid)
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/fogfish/guid/jsonk
+
+go 1.23
+
+require (
+	github.com/fogfish/guid/v2 v2.0.0
+	github.com/fogfish/it/v2 v2.0.1
+	github.com/goccy/go-json v0.11.1
+	github.com/json-iterator/go v1.1.12
+	github.com/modern-go/reflect2 v1.0.2
+)
+
+require github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+
+replace github.com/fogfish/guid/v2 => ../
@@ -0,0 +1,112 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package jsonk adapts k-ordered values to alternative JSON encoders
+// (jsoniter, goccy/go-json). Both honor MarshalJSON of guid.K values and
+// MarshalText of guid.K map keys. The package pins the wire format for
+// encoders configured to bypass TextMarshaler.
+//
+// Register Extension with jsoniter to encode map[guid.K]V:
+//
+//	json := jsoniter.ConfigCompatibleWithStandardLibrary
+//	json.RegisterExtension(&jsonk.Extension{})
+//
+// Use K as map key with goccy/go-json, which has no extension points:
+//
+//	map[jsonk.K]V
+package jsonk
+
+import (
+	"unsafe"
+
+	"github.com/fogfish/guid/v2"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+// K is k-ordered value, which implements encoding.TextMarshaler and
+// encoding.TextUnmarshaler, so that encoders accept it as map key. The text
+// form is the same as JSON string of guid.K.
+type K guid.K
+
+// MarshalText encodes k-ordered value to text
+func (uid K) MarshalText() ([]byte, error) {
+	b, err := guid.K(uid).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return b[1 : len(b)-1], nil
+}
+
+// UnmarshalText decodes k-ordered value from text
+func (uid *K) UnmarshalText(b []byte) error {
+	k, err := guid.FromString(string(b))
+	if err != nil {
+		return err
+	}
+
+	*uid = K(k)
+	return nil
+}
+
+// MarshalJSON encodes k-ordered value, same as guid.K
+func (uid K) MarshalJSON() ([]byte, error) { return guid.K(uid).MarshalJSON() }
+
+// UnmarshalJSON decodes k-ordered value, same as guid.K
+func (uid *K) UnmarshalJSON(b []byte) error { return (*guid.K)(uid).UnmarshalJSON(b) }
+
+// Extension of jsoniter, which encodes guid.K as map key
+type Extension struct{ jsoniter.DummyExtension }
+
+var typeOfK = reflect2.TypeOf(guid.K{})
+
+func (Extension) CreateMapKeyEncoder(typ reflect2.Type) jsoniter.ValEncoder {
+	if typ == typeOfK {
+		return keyCodec{}
+	}
+	return nil
+}
+
+func (Extension) CreateMapKeyDecoder(typ reflect2.Type) jsoniter.ValDecoder {
+	if typ == typeOfK {
+		return keyCodec{}
+	}
+	return nil
+}
+
+type keyCodec struct{}
+
+func (keyCodec) IsEmpty(ptr unsafe.Pointer) bool { return false }
+
+func (keyCodec) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	b, err := (*guid.K)(ptr).MarshalJSON()
+	if err != nil {
+		stream.Error = err
+		return
+	}
+	stream.Write(b)
+}
+
+func (keyCodec) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	uid, err := guid.FromString(iter.ReadString())
+	if err != nil {
+		iter.ReportError("decode guid.K", err.Error())
+		return
+	}
+	*(*guid.K)(ptr) = uid
+}
@@ -0,0 +1,125 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package jsonk_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/jsonk"
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+	gojson "github.com/goccy/go-json"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type T struct {
+	G guid.K  `json:"g"`
+	L guid.K  `json:"l"`
+	P *guid.K `json:"p"`
+}
+
+func TestValues(t *testing.T) {
+	g := guid.G(guid.Clock)
+	val := T{G: g, L: guid.L(guid.Clock), P: &g}
+
+	for name, codec := range map[string]struct {
+		marshal   func(any) ([]byte, error)
+		unmarshal func([]byte, any) error
+	}{
+		"jsoniter": {jsoniter.ConfigFastest.Marshal, jsoniter.ConfigFastest.Unmarshal},
+		"go-json":  {gojson.Marshal, gojson.Unmarshal},
+	} {
+		t.Run(name, func(t *testing.T) {
+			b, err := codec.marshal(val)
+			it.Then(t).Should(it.Nil(err))
+
+			var x T
+			err = codec.unmarshal(b, &x)
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equal(x.G, val.G),
+				it.Equal(x.L, val.L),
+				it.Equal(*x.P, g),
+			)
+		})
+	}
+}
+
+func TestExtension(t *testing.T) {
+	json := jsoniter.Config{}.Froze()
+	json.RegisterExtension(&jsonk.Extension{})
+
+	g := guid.G(guid.Clock)
+	l := guid.L(guid.Clock)
+	val := map[guid.K]int{g: 1, l: 2}
+
+	b, err := json.Marshal(val)
+	it.Then(t).Should(it.Nil(err))
+
+	var x map[guid.K]int
+	err = json.Unmarshal(b, &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x[g], 1),
+		it.Equal(x[l], 2),
+	).ShouldNot(
+		it.Nil(json.Unmarshal([]byte(`{"!":1}`), &x)),
+	)
+}
+
+func TestKey(t *testing.T) {
+	g := jsonk.K(guid.G(guid.Clock))
+	l := jsonk.K(guid.L(guid.Clock))
+	val := map[jsonk.K]jsonk.K{g: l, l: g}
+
+	b, err := gojson.Marshal(val)
+	it.Then(t).Should(it.Nil(err))
+
+	var x map[jsonk.K]jsonk.K
+	err = gojson.Unmarshal(b, &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x[g], l),
+		it.Equal(x[l], g),
+	)
+
+	b, _ = jsoniter.Marshal(g)
+	c, _ := guid.K(g).MarshalJSON()
+	it.Then(t).Should(
+		it.Equal(string(b), string(c)),
+	)
+}
+
+func TestMapKey(t *testing.T) {
+	g := guid.G(guid.Clock)
+	val := map[guid.K]int{g: 1}
+
+	for name, marshal := range map[string]func(any) ([]byte, error){
+		"jsoniter": jsoniter.Marshal,
+		"go-json":  gojson.Marshal,
+	} {
+		t.Run(name, func(t *testing.T) {
+			b, err := marshal(val)
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equal(string(b), `{"`+g.String()+`":1}`),
+			)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// MapToJSON encodes map to JSON object, keys are emitted in k-order (same as
+// Before), which makes payloads deterministic.
+func MapToJSON[V any](m map[K]V) ([]byte, error) {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, CompareClassMajor)
+
+	b := make([]byte, 0, len(m)*32+2)
+	b = append(b, '{')
+	for i, k := range keys {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		v, err := json.Marshal(m[k])
+		if err != nil {
+			return nil, err
+		}
+
+		b = appendJSON(b, k)
+		b = append(b, ':')
+		b = append(b, v...)
+	}
+	return append(b, '}'), nil
+}
+
+// MapFromJSON decodes JSON object to map, it is inverse to MapToJSON
+func MapFromJSON[V any](b []byte) (map[K]V, error) {
+	var m map[K]V
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
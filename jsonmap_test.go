@@ -0,0 +1,67 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestMapToJSON(t *testing.T) {
+	c := guid.NewClock(guid.WithNodeID(0xa))
+	a, b, l := guid.G(c), guid.G(c), guid.L(c)
+	m := map[guid.K]int{b: 2, a: 1, l: 3, guid.Nil(): 0}
+
+	x, err := guid.MapToJSON(m)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(x), `{"":0,"`+guid.StringLocal(l)+`":3,"`+a.String()+`":1,"`+b.String()+`":2}`),
+	)
+
+	y, err := guid.MapFromJSON[int](x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(y), 4),
+		it.Equal(y[a], 1),
+		it.Equal(y[b], 2),
+		it.Equal(y[l], 3),
+		it.Equal(y[guid.Nil()], 0),
+	)
+
+	_, err = guid.MapFromJSON[int]([]byte(`{"!":1}`))
+	it.Then(t).ShouldNot(it.Nil(err))
+}
+
+func TestTextCodec(t *testing.T) {
+	g := guid.G(guid.Clock)
+	m := map[guid.K]guid.K{g: guid.ToL(g)}
+
+	b, err := json.Marshal(m)
+	it.Then(t).Should(it.Nil(err))
+
+	var x map[guid.K]guid.K
+	err = json.Unmarshal(b, &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x[g], guid.ToL(g)),
+	)
+}
@@ -0,0 +1,33 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import intern "unique"
+
+// Key returns canonical binary form of k-ordered value as string, usable as
+// compact map key. The key is 12 bytes for global and 8 bytes for local values.
+// Keys are interned, equal identifiers share the same storage.
+func Key(uid K) string {
+	return intern.Make(string(Bytes(uid))).Value()
+}
+
+// FromKey decodes k-ordered value from the key produced by Key.
+func FromKey(key string) (K, error) {
+	return FromBytes([]byte(key))
+}
@@ -0,0 +1,70 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestKey(t *testing.T) {
+	g := guid.G(guid.Clock)
+	l := guid.L(guid.Clock)
+
+	t.Run("G", func(t *testing.T) {
+		key := guid.Key(g)
+		uid, err := guid.FromKey(key)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(len(key), 12),
+			it.Equal(uid, g),
+		)
+	})
+
+	t.Run("L", func(t *testing.T) {
+		key := guid.Key(l)
+		uid, err := guid.FromKey(key)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(len(key), 8),
+			it.Equal(uid, l),
+		)
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		idx := map[string]int{guid.Key(g): 1, guid.Key(l): 2}
+
+		it.Then(t).Should(
+			it.Equal(idx[guid.Key(g)], 1),
+			it.Equal(idx[guid.Key(l)], 2),
+		)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := guid.FromKey("abc")
+
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	})
+}
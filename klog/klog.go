@@ -0,0 +1,180 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package klog implements append-only log of k-ordered values. The log is
+// a file of fixed-size records, 12 bytes big-endian each, local values are
+// padded with zero ⟨𝒅, 𝒕⟩ prefix of global layout (same as arrowk). The
+// trailing partial record, left by interrupted write, is ignored by reader.
+//
+// The reader memory-maps the file, it supports binary search by ⟨𝒕⟩ and
+// iteration by time range. The search assumes records are appended in the
+// order of ⟨𝒕⟩ timestamps (e.g. single writer, global values of the same
+// drift), k-ordered streams are searched within k records precision.
+package klog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// RecordSize is size of the log record in bytes
+const RecordSize = 12
+
+// Writer appends k-ordered values to the log
+type Writer struct {
+	w      *bufio.Writer
+	closer io.Closer
+	buf    [RecordSize]byte
+}
+
+// Create opens the log file for appending, the file is created if needed
+func Create(path string) (*Writer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{w: bufio.NewWriter(f), closer: f}, nil
+}
+
+// NewWriter creates log writer on top of the stream
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Append writes k-ordered value to the log, delete-markers are rejected as
+// fixed-size records do not carry them (see guid.TombstoneOf)
+func (w *Writer) Append(uid guid.K) error {
+	if guid.IsTombstone(uid) {
+		return errors.New("klog: delete-marker is not supported")
+	}
+
+	binary.BigEndian.PutUint32(w.buf[0:4], uint32(uid.Hi))
+	binary.BigEndian.PutUint64(w.buf[4:12], uid.Lo)
+	_, err := w.w.Write(w.buf[:])
+	return err
+}
+
+// Flush writes buffered records to underlying stream
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+// Close flushes buffered records and closes the log file
+func (w *Writer) Close() error {
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+
+	if w.closer != nil {
+		return w.closer.Close()
+	}
+	return nil
+}
+
+// Reader is random access reader of the log
+type Reader struct {
+	data  []byte
+	unmap func() error
+}
+
+// Open memory-maps the log file for reading
+func Open(path string) (*Reader, error) {
+	data, unmap, err := mmap(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{data: data, unmap: unmap}, nil
+}
+
+// NewReader creates log reader on top of in-memory log
+func NewReader(data []byte) *Reader {
+	return &Reader{data: data}
+}
+
+// Close releases memory mapping of the log, values read from the log stay valid
+func (r *Reader) Close() error {
+	data := r.data
+	r.data = nil
+
+	if r.unmap != nil && data != nil {
+		return r.unmap()
+	}
+	return nil
+}
+
+// Len returns number of records in the log
+func (r *Reader) Len() int {
+	return len(r.data) / RecordSize
+}
+
+// At returns i-th record of the log
+func (r *Reader) At(i int) guid.K {
+	b := r.data[RecordSize*i : RecordSize*(i+1)]
+	return guid.K{
+		Hi: uint64(binary.BigEndian.Uint32(b[0:4])),
+		Lo: binary.BigEndian.Uint64(b[4:12]),
+	}
+}
+
+// ⟨𝒕⟩ timestamp of identifiers has ~131µs precision
+func tick(t time.Time) uint64 {
+	return uint64(t.UnixNano()) >> 17 << 17
+}
+
+// Search returns index of the first record with ⟨𝒕⟩ timestamp not before t,
+// Len if there is no such record.
+func (r *Reader) Search(t time.Time) int {
+	ts := tick(t)
+	return sort.Search(r.Len(), func(i int) bool {
+		return guid.Time(r.At(i)) >= ts
+	})
+}
+
+// Range iterates over records with ⟨𝒕⟩ timestamp within [from, to)
+func (r *Reader) Range(from, to time.Time) iter.Seq2[int, guid.K] {
+	return func(yield func(int, guid.K) bool) {
+		ts := tick(to)
+		for i := r.Search(from); i < r.Len(); i++ {
+			uid := r.At(i)
+			if guid.Time(uid) >= ts || !yield(i, uid) {
+				return
+			}
+		}
+	}
+}
+
+// All iterates over all records of the log
+func (r *Reader) All() iter.Seq2[int, guid.K] {
+	return func(yield func(int, guid.K) bool) {
+		for i := 0; i < r.Len(); i++ {
+			if !yield(i, r.At(i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package klog_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/klog"
+	"github.com/fogfish/it/v2"
+)
+
+// identifiers allocated every second from base timestamp
+func stream(n int) []guid.K {
+	t := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]guid.K, n)
+	for i := range ids {
+		ids[i] = guid.FromT(t.Add(time.Duration(i) * time.Second))
+	}
+	return ids
+}
+
+func TestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.klog")
+	ids := stream(100)
+	ids[3] = guid.ToL(ids[3])
+
+	w, err := klog.Create(path)
+	it.Then(t).Should(it.Nil(err))
+	for _, uid := range ids {
+		it.Then(t).Should(it.Nil(w.Append(uid)))
+	}
+	it.Then(t).Should(it.Nil(w.Close()))
+	it.Then(t).ShouldNot(it.Nil(w.Append(guid.TombstoneOf(ids[0]))))
+
+	// partial record of interrupted write
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	f.Write([]byte{1, 2, 3})
+	f.Close()
+
+	r, err := klog.Open(path)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(r.Len(), 100),
+	)
+
+	seq := make([]guid.K, 0, r.Len())
+	for _, uid := range r.All() {
+		seq = append(seq, uid)
+	}
+	it.Then(t).Should(
+		it.Seq(seq).Equal(ids...),
+		it.Nil(r.Close()),
+	)
+}
+
+func TestSearch(t *testing.T) {
+	var buf bytes.Buffer
+	ids := stream(100)
+
+	w := klog.NewWriter(&buf)
+	for _, uid := range ids {
+		w.Append(uid)
+	}
+	w.Flush()
+
+	r := klog.NewReader(buf.Bytes())
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	seq := []int{}
+	for i, uid := range r.Range(base.Add(10*time.Second), base.Add(15*time.Second)) {
+		it.Then(t).Should(it.Equal(uid, ids[i]))
+		seq = append(seq, i)
+	}
+
+	it.Then(t).Should(
+		it.Equal(r.Search(base), 0),
+		it.Equal(r.Search(base.Add(time.Hour)), 100),
+		it.Seq(seq).Equal(10, 11, 12, 13, 14),
+	)
+}
+
+func TestOpenEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.klog")
+	os.WriteFile(path, nil, 0644)
+
+	r, err := klog.Open(path)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(r.Len(), 0),
+		it.Nil(r.Close()),
+	)
+}
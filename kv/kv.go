@@ -0,0 +1,117 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package kv implements composite keys of embedded key-value stores
+// (LevelDB, Badger, Pebble) built from prefix and k-ordered value.
+//
+// The composite key is prefix ‖ binary identifier (8 or 12 bytes, see
+// guid.Bytes) ‖ length of identifier. The trailing length makes key
+// self-describing, so that key is split without knowing the prefix.
+//
+// Compare and Split are compatible with Pebble's custom comparer:
+//
+//	pebble.Comparer{Compare: kv.Compare, Split: kv.Split, ...}
+package kv
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// Join builds composite key from prefix and k-ordered value
+func Join(prefix []byte, uid guid.K) []byte {
+	b := guid.Bytes(uid)
+
+	key := make([]byte, 0, len(prefix)+len(b)+1)
+	key = append(key, prefix...)
+	key = append(key, b...)
+	key = append(key, byte(len(b)))
+	return key
+}
+
+// SplitKey decomposes composite key to prefix and k-ordered value
+func SplitKey(key []byte) ([]byte, guid.K, error) {
+	n := Split(key)
+	if n == len(key) {
+		return nil, guid.K{}, fmt.Errorf("malformed k-order key: %x", key)
+	}
+
+	uid, err := guid.FromBytes(key[n : len(key)-1])
+	if err != nil {
+		return nil, guid.K{}, err
+	}
+
+	return key[:n], uid, nil
+}
+
+// Split returns length of prefix of composite key. Malformed key has no
+// identifier, the whole key is prefix, Split returns len(key).
+func Split(key []byte) int {
+	if len(key) == 0 {
+		return 0
+	}
+
+	// 13 bytes of global value carry delete-marker (see guid.TombstoneOf)
+	n := int(key[len(key)-1])
+	if (n != 8 && n != 12 && n != 13) || len(key) < n+1 {
+		return len(key)
+	}
+
+	return len(key) - n - 1
+}
+
+// Compare composite keys, prefixes are compared byte-wise, identifiers with
+// the same prefix are compared in k-order. Malformed keys have no identifier
+// (see Split), they precede well-formed keys of the same prefix.
+func Compare(a, b []byte) int {
+	na, nb := Split(a), Split(b)
+	if c := bytes.Compare(a[:na], b[:nb]); c != 0 {
+		return c
+	}
+
+	// local (8 bytes) identifiers precede global one as guid.Before does
+	ua, ub := suffix(a, na), suffix(b, nb)
+	if ra, rb := rank(ua), rank(ub); ra != rb {
+		return ra - rb
+	}
+
+	return bytes.Compare(ua, ub)
+}
+
+// identifier of composite key, it is empty for malformed keys
+func suffix(key []byte, n int) []byte {
+	if n == len(key) {
+		return nil
+	}
+	return key[n : len(key)-1]
+}
+
+// rank of identifier: none, local and global one. The delete-marker is the
+// trailing zero byte of global one, it is ordered right after the value.
+func rank(uid []byte) int {
+	switch len(uid) {
+	case 0:
+		return 0
+	case 8:
+		return 1
+	default:
+		return 2
+	}
+}
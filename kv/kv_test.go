@@ -0,0 +1,114 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package kv_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/kv"
+	"github.com/fogfish/it/v2"
+)
+
+func TestJoin(t *testing.T) {
+	g := guid.G(guid.Clock)
+	l := guid.L(guid.Clock)
+
+	for _, uid := range []guid.K{g, l, guid.TombstoneOf(g)} {
+		prefix, x, err := kv.SplitKey(kv.Join([]byte("user/"), uid))
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(string(prefix), "user/"),
+			it.Equal(x, uid),
+		)
+	}
+
+	_, _, err := kv.SplitKey([]byte("user/"))
+	it.Then(t).ShouldNot(
+		it.Nil(err),
+	)
+}
+
+func TestCompare(t *testing.T) {
+	a := guid.G(guid.Clock)
+	b := guid.G(guid.Clock)
+	l := guid.L(guid.Clock)
+
+	keys := [][]byte{
+		kv.Join([]byte("b"), a),
+		kv.Join([]byte("a"), b),
+		kv.Join([]byte("a"), a),
+		kv.Join([]byte("a"), l),
+	}
+	slices.SortFunc(keys, kv.Compare)
+
+	it.Then(t).Should(
+		it.Equal(string(keys[0]), string(kv.Join([]byte("a"), l))),
+		it.Equal(string(keys[1]), string(kv.Join([]byte("a"), a))),
+		it.Equal(string(keys[2]), string(kv.Join([]byte("a"), b))),
+		it.Equal(string(keys[3]), string(kv.Join([]byte("b"), a))),
+		it.Equal(kv.Split(keys[0]), 1),
+	)
+}
+
+func TestCompareMalformed(t *testing.T) {
+	a := guid.G(guid.Clock)
+	b := guid.G(guid.Clock)
+	l := guid.L(guid.Clock)
+
+	keys := [][]byte{
+		kv.Join([]byte("a"), b),
+		kv.Join([]byte("a"), guid.TombstoneOf(a)),
+		[]byte("a"),
+		kv.Join([]byte("a"), a),
+		[]byte("b\xff"),
+		kv.Join([]byte("a"), l),
+		{},
+		kv.Join([]byte("b"), a),
+	}
+	slices.SortFunc(keys, kv.Compare)
+
+	it.Then(t).Should(
+		it.Equal(string(keys[0]), ""),
+		it.Equal(string(keys[1]), "a"),
+		it.Equal(string(keys[2]), string(kv.Join([]byte("a"), l))),
+		it.Equal(string(keys[3]), string(kv.Join([]byte("a"), a))),
+		it.Equal(string(keys[4]), string(kv.Join([]byte("a"), guid.TombstoneOf(a)))),
+		it.Equal(string(keys[5]), string(kv.Join([]byte("a"), b))),
+		it.Equal(string(keys[6]), string(kv.Join([]byte("b"), a))),
+		it.Equal(string(keys[7]), "b\xff"),
+	)
+
+	// comparison is antisymmetric
+	for _, x := range keys {
+		for _, y := range keys {
+			it.Then(t).Should(
+				it.Equal(kv.Compare(x, y), -kv.Compare(y, x)),
+			)
+		}
+	}
+
+	it.Then(t).Should(
+		it.Equal(kv.Split(nil), 0),
+		it.Equal(kv.Split([]byte("user/")), 5),
+		it.Equal(kv.Split([]byte("user/\x0c")), 6),
+	)
+}
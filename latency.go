@@ -0,0 +1,40 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "time"
+
+// Latency bounds the duration between allocation of k-ordered value and the
+// wall clock. The bounds account for the granularity of ⟨𝒕⟩ timestamp (the
+// tick of ⟨𝒔⟩ sequence, 2¹⁷ ns) and for the drift window of the identifier,
+// clocks of allocating and observing nodes are assumed to be skewed within
+// the drift. The lower bound is never negative unless the identifier is
+// ahead of the wall clock beyond the drift, both bounds are negative then.
+func Latency(uid K, now time.Time) (min, max time.Duration) {
+	const tick = time.Duration(1 << bitsSeqDrift)
+
+	drift := driftStepOf(driftOf(uid))
+	elapsed := time.Duration(now.UnixNano() - int64(Time(uid)))
+
+	min, max = elapsed-tick-drift, elapsed+drift
+	if min < 0 && max >= 0 {
+		min = 0
+	}
+	return min, max
+}
@@ -0,0 +1,55 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestLatency(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	uid := guid.G(
+		guid.NewClock(guid.WithClock(func() uint64 { return uint64(at.UnixNano()) })),
+		time.Minute,
+	)
+
+	min, max := guid.Latency(uid, at.Add(time.Hour))
+	it.Then(t).Should(
+		it.True(min <= time.Hour),
+		it.True(max >= time.Hour),
+		it.True(min > time.Hour-68*time.Second-time.Millisecond),
+		it.True(max < time.Hour+68*time.Second+time.Millisecond),
+	)
+
+	min, max = guid.Latency(uid, at)
+	it.Then(t).Should(
+		it.Equal(min, 0),
+		it.True(max >= 68*time.Second),
+	)
+
+	min, max = guid.Latency(uid, at.Add(-time.Hour))
+	it.Then(t).Should(
+		it.True(min < 0),
+		it.True(max < 0),
+	)
+}
@@ -0,0 +1,111 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// SequenceLease is a block of ⟨𝒔⟩ sequence numbers leased by goroutine from
+// the clock. The lease mints identifiers locally without touching shared
+// atomics. The lease is not safe for concurrent use, each goroutine leases
+// its own block.
+type SequenceLease struct {
+	clock      *clock
+	n          int64
+	next, last int64
+}
+
+// Lease reserves the block of n sequence numbers from the clock, the block is
+// renewed when it is exhausted. Clocks with custom sequence generator (e.g.
+// WithUnique) do not reserve blocks, the lease draws numbers from generator.
+func (clock clock) Lease(n int) *SequenceLease {
+	if n <= 0 || n > 0x4000 {
+		panic("guid: lease size must be within 1 and 16384")
+	}
+
+	lease := &SequenceLease{clock: &clock, n: int64(n)}
+	lease.renew()
+	return lease
+}
+
+func (lease *SequenceLease) renew() {
+	if lease.clock.counter == nil {
+		return
+	}
+
+	step := lease.clock.step
+	lease.last = atomic.AddInt64(lease.clock.counter, step*lease.n)
+	lease.next = lease.last - step*(lease.n-1)
+}
+
+func (lease *SequenceLease) seq() uint64 {
+	if lease.clock.counter == nil {
+		return lease.clock.unique() & 0x3fff
+	}
+
+	if lease.next-lease.last == lease.clock.step {
+		lease.renew()
+	}
+
+	seq := lease.next
+	lease.next += lease.clock.step
+	return uint64(seq & 0x3fff)
+}
+
+// G generates globally unique 96-bit k-ordered identifier using leased sequence
+func (lease *SequenceLease) G(drift ...time.Duration) K {
+	t, d, seq := lease.observe(drift)
+	return makeG(lease.clock.location, d, t, seq)
+}
+
+// L generates locally unique 64-bit k-ordered identifier using leased sequence
+func (lease *SequenceLease) L(drift ...time.Duration) K {
+	t, d, seq := lease.observe(drift)
+	return makeL(d, t, seq)
+}
+
+func (lease *SequenceLease) observe(drift []time.Duration) (t, d, seq uint64) {
+	if j := lease.clock.journal; j != nil {
+		j.Lock()
+		defer j.Unlock()
+		t, d = lease.clock.tick(drift)
+		seq = lease.seq()
+		j.write(t, lease.clock.location, seq)
+		return
+	}
+
+	t, d = lease.clock.tick(drift)
+	return t, d, lease.seq()
+}
+
+// Close returns unused sequence numbers to the clock if no other block has
+// been leased since, otherwise they are discarded.
+func (lease *SequenceLease) Close() error {
+	if lease.clock.counter == nil {
+		return nil
+	}
+
+	if lease.next-lease.last != lease.clock.step {
+		atomic.CompareAndSwapInt64(lease.clock.counter, lease.last, lease.next-lease.clock.step)
+	}
+	lease.next = lease.last + lease.clock.step
+	return nil
+}
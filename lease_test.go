@@ -0,0 +1,78 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestLease(t *testing.T) {
+	for _, c := range []guid.LogicalClock{
+		guid.NewClock(guid.WithNodeID(0xa)),
+		guid.NewClock(guid.WithNodeID(0xa), guid.WithClockInverse()),
+	} {
+		lease := c.Lease(4)
+
+		seq := make([]guid.K, 10)
+		for i := range seq {
+			seq[i] = lease.G()
+		}
+		lease.Close()
+
+		seen := map[uint64]struct{}{}
+		for _, uid := range seq {
+			seen[guid.Seq(uid)] = struct{}{}
+		}
+
+		it.Then(t).Should(
+			it.Equal(guid.Node(seq[0]), 0xa),
+			it.Equal(len(seen), len(seq)),
+		)
+	}
+}
+
+func TestLeaseClose(t *testing.T) {
+	c := guid.NewClock()
+
+	lease := c.Lease(100)
+	a := lease.L()
+	lease.Close()
+
+	// unused numbers are returned, next identifier continues the sequence
+	b := guid.L(c)
+
+	it.Then(t).Should(
+		it.Equal(guid.Seq(b), (guid.Seq(a)+1)&0x3fff),
+	)
+}
+
+func TestLeaseCustomUnique(t *testing.T) {
+	c := guid.NewClock(guid.WithUnique(func() uint64 { return 7 }))
+
+	lease := c.Lease(4)
+	a, b := lease.G(), lease.G()
+	it.Then(t).Should(
+		it.Nil(lease.Close()),
+		it.Equal(guid.Seq(a), 7),
+		it.Equal(guid.Seq(b), 7),
+	)
+}
@@ -0,0 +1,98 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LimitedGenerator allocates globally unique k-ordered identifiers with
+// throttling to configured rate. It implements token bucket algorithm,
+// the bucket capacity is equal to rate so that one second burst is allowed.
+type LimitedGenerator struct {
+	mu     sync.Mutex
+	clock  Chronos
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewLimitedGenerator creates generator of identifiers, which allocates at
+// most ratePerSec identifiers per second using the clock.
+func NewLimitedGenerator(clock Chronos, ratePerSec int) *LimitedGenerator {
+	if ratePerSec <= 0 {
+		panic("guid: rate of limited generator must be positive")
+	}
+
+	return &LimitedGenerator{
+		clock:  clock,
+		rate:   float64(ratePerSec),
+		tokens: float64(ratePerSec),
+		last:   time.Now(),
+	}
+}
+
+// takes the token from bucket, returns time to wait for the token if bucket is empty
+func (gen *LimitedGenerator) take() time.Duration {
+	gen.mu.Lock()
+	defer gen.mu.Unlock()
+
+	now := time.Now()
+	gen.tokens += now.Sub(gen.last).Seconds() * gen.rate
+	gen.last = now
+	if gen.tokens > gen.rate {
+		gen.tokens = gen.rate
+	}
+
+	if gen.tokens >= 1 {
+		gen.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - gen.tokens) / gen.rate * float64(time.Second))
+}
+
+// TryG generates globally unique 96-bit k-ordered identifier if the rate
+// permits, otherwise it returns false.
+func (gen *LimitedGenerator) TryG(drift ...time.Duration) (K, bool) {
+	if gen.take() != 0 {
+		return K{}, false
+	}
+
+	return G(gen.clock, drift...), true
+}
+
+// G generates globally unique 96-bit k-ordered identifier, blocking until
+// the rate permits or context is cancelled.
+func (gen *LimitedGenerator) G(ctx context.Context, drift ...time.Duration) (K, error) {
+	for {
+		wait := gen.take()
+		if wait == 0 {
+			return G(gen.clock, drift...), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return K{}, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,52 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestLimitedGenerator(t *testing.T) {
+	gen := guid.NewLimitedGenerator(guid.NewClock(), 10)
+
+	for i := 0; i < 10; i++ {
+		_, ok := gen.TryG()
+		it.Then(t).Should(it.True(ok))
+	}
+
+	_, ok := gen.TryG()
+	it.Then(t).ShouldNot(it.True(ok))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := gen.G(ctx)
+	it.Then(t).Should(it.Nil(err))
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	_, err = gen.G(ctx)
+	it.Then(t).ShouldNot(it.Nil(err))
+}
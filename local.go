@@ -0,0 +1,82 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "fmt"
+
+// length of canonical local string, 11 cells of 6 bits
+const charsInL = 11
+
+// StringLocal encodes local k-ordered value to canonical lexicographically
+// sortable string of 11 chars, distinguishable from 16 chars of global one.
+// Global values are projected with ToL.
+func StringLocal(uid K) string {
+	var enc [charsInL]byte
+	encodeLocal(ToL(uid).Lo, &enc)
+	return string(enc[:])
+}
+
+func encodeLocal(lo uint64, enc *[charsInL]byte) {
+	for i := 0; i < charsInL; i++ {
+		enc[i] = alphabet[lo>>(60-6*i)&0x3f]
+	}
+}
+
+func appendLocal(dst []byte, uid K) []byte {
+	var enc [charsInL]byte
+	encodeLocal(uid.Lo, &enc)
+	return append(dst, enc[:]...)
+}
+
+// FromStringLocal decodes local k-ordered value from canonical string
+func FromStringLocal(val string) (K, error) {
+	if len(val) != charsInL {
+		return K{}, fmt.Errorf("malformed k-order number: %v", val)
+	}
+
+	var lo uint64
+	for i := 0; i < charsInL; i++ {
+		x := decoder64(val[i])
+		// the first cell carries 4 bits only
+		if x > 0x3f || (i == 0 && x > 0xf) {
+			return K{}, fmt.Errorf("malformed k-order number: %v", val)
+		}
+		lo = lo<<6 | uint64(x)
+	}
+
+	return K{Lo: lo}, nil
+}
+
+// decodes char of sortable alphabet, invalid chars are 0xff
+func decoder64(x byte) byte {
+	switch {
+	case x == '.':
+		return 0
+	case x >= '0' && x <= '9':
+		return x - '0' + 1
+	case x >= 'A' && x <= 'Z':
+		return x - 'A' + 11
+	case x == '_':
+		return 37
+	case x >= 'a' && x <= 'z':
+		return x - 'a' + 38
+	default:
+		return 0xff
+	}
+}
@@ -0,0 +1,69 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestStringLocal(t *testing.T) {
+	g := guid.G(guid.Clock)
+	l := guid.ToL(g)
+	s := guid.StringLocal(l)
+
+	x, err := guid.FromStringLocal(s)
+	it.Then(t).Should(
+		it.Equal(len(s), 11),
+		it.Equal(guid.StringLocal(g), s),
+		it.Nil(err),
+		it.Equal(x, l),
+	)
+
+	y, err := guid.FromString(s)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(y, l),
+	)
+
+	for _, val := range []string{"", "0123456789", "G0000000000", "0000000000!"} {
+		_, err := guid.FromStringLocal(val)
+		it.Then(t).ShouldNot(it.Nil(err))
+	}
+}
+
+func TestStringLocalSort(t *testing.T) {
+	ids := make([]guid.K, 0, 100)
+	strs := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		uid := guid.FromT(time.Unix(int64(i)*7919, 0))
+		ids = append(ids, uid)
+		strs = append(strs, guid.StringLocal(uid))
+	}
+
+	it.Then(t).Should(
+		it.True(sort.StringsAreSorted(strs)),
+		it.Equal(guid.StringLocal(guid.K{Lo: 0xffffffffffffffff}), "Ezzzzzzzzzz"),
+		it.Equal(guid.StringLocal(guid.K{}), "..........."),
+	)
+}
@@ -0,0 +1,100 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// confusable characters of human-edited sources, mapped to the alphabet
+var confusables = map[rune]rune{
+	// dots
+	'·': '.', '•': '.', '‧': '.', '。': '.', '｡': '.', '․': '.',
+	// underscores
+	'‗': '_', '＿': '_',
+	// Cyrillic look-alikes
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O',
+	'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'а': 'a', 'е': 'e', 'о': 'o',
+	'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x',
+	// Greek look-alikes
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K',
+	'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	'ο': 'o',
+}
+
+// quotes wrapping values at spreadsheets and tickets
+var quotes = [][2]string{{`"`, `"`}, {`'`, `'`}, {"`", "`"}, {"“", "”"}, {"‘", "’"}}
+
+// ParseLoose decodes k-ordered value from human-edited sources (tickets,
+// spreadsheets). It trims whitespace and wrapping quotes, decodes URL-encoded
+// form, removes zero-width characters and maps confusable characters (e.g.
+// fullwidth forms, Cyrillic and Greek look-alikes) to the alphabet before
+// strict validation of the alphabet and canonical form (see Strict), empty
+// and Nil values are rejected. The alphabet is case-sensitive and valid
+// characters are never substituted (e.g. O and 0), the noise is not guessed.
+func ParseLoose(s string) (K, error) {
+	val := strings.TrimSpace(s)
+	if strings.ContainsRune(val, '%') {
+		if x, err := url.PathUnescape(val); err == nil {
+			val = strings.TrimSpace(x)
+		}
+	}
+
+	for _, q := range quotes {
+		if len(val) >= len(q[0])+len(q[1]) && strings.HasPrefix(val, q[0]) && strings.HasSuffix(val, q[1]) {
+			val = strings.TrimSpace(val[len(q[0]) : len(val)-len(q[1])])
+			break
+		}
+	}
+
+	val = strings.Map(normalize, val)
+	if val == "" || !isAlphabet64(val) {
+		return K{}, fmt.Errorf("malformed k-order number: %v", s)
+	}
+
+	uid, err := Strict(FromString(val))
+	switch {
+	case err != nil:
+		return K{}, err
+	case uid == Nil():
+		return K{}, fmt.Errorf("malformed k-order number: %v", s)
+	default:
+		return uid, nil
+	}
+}
+
+func normalize(r rune) rune {
+	switch {
+	case r < unicode.MaxASCII:
+		return r
+	case r == '\u200b' || r == '\u200c' || r == '\u200d' || r == '\u2060' || r == '\ufeff':
+		return -1
+	case r >= '！' && r <= '～':
+		// fullwidth forms of ASCII
+		return r - 0xfee0
+	}
+
+	if x, has := confusables[r]; has {
+		return x
+	}
+	return r
+}
@@ -0,0 +1,86 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestParseLoose(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+	s := guid.String(uid)
+
+	fullwidth := strings.Map(func(r rune) rune {
+		if r > ' ' && r < 0x7f {
+			return r + 0xfee0
+		}
+		return r
+	}, s)
+
+	for _, val := range []string{
+		s,
+		"  " + s + "\t\n",
+		`"` + s + `"`,
+		"“" + s + "”",
+		url.PathEscape(" " + s + " "),
+		strings.ReplaceAll(s, ".", "%2E"),
+		"\ufeff" + s + "\u200b",
+		fullwidth,
+	} {
+		x, err := guid.ParseLoose(val)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(x, uid),
+		)
+	}
+
+	x, err := guid.ParseLoose("NljBVm51PwMrZR·1")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(guid.String(x), "NljBVm51PwMrZR.1"),
+	)
+
+	x, err = guid.ParseLoose("NljBVm51PwМrZR.1") // Cyrillic М
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(guid.String(x), "NljBVm51PwMrZR.1"),
+	)
+
+	for _, val := range []string{
+		"",
+		"  ",
+		`""`,
+		"!!!",
+		"!!!!!!!!!!!!!!!!",
+		"----------------",
+		"................",
+		"Nlj BVm51PwMrZR.1",
+		`"NljBVm51PwMrZR.1`,
+	} {
+		_, err := guid.ParseLoose(val)
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	}
+}
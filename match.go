@@ -0,0 +1,117 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "time"
+
+// MatchSpec defines predicate over fractions of identifiers. Ranges of node
+// locations and sequences are checked unless AnyNode or AnySeq is set, so that
+// zero node and sequence are matchable. Zero time disables corresponding bound
+// of the window.
+type MatchSpec struct {
+	// Inclusive range of node locations ⟨𝒍⟩, local values have zero location
+	NodeFrom, NodeTo uint32
+	AnyNode          bool
+	// Half-open window [From, To) of ⟨𝒕⟩ timestamps
+	From, To time.Time
+	// Inclusive range of sequence ⟨𝒔⟩
+	SeqFrom, SeqTo uint16
+	AnySeq         bool
+}
+
+// bound is mask/value pair of the fraction, the masked identifier is
+// within inclusive range [lo, hi] if the fraction is within the range.
+type bound struct{ mask, lo, hi K }
+
+func (b *bound) has(uid K) bool {
+	x := K{Hi: uid.Hi & b.mask.Hi, Lo: uid.Lo & b.mask.Lo}
+	return !Before(x, b.lo) && !Before(b.hi, x)
+}
+
+// Match compiles specification into predicate, which filters streams of
+// identifiers. The specification is translated into mask/value bounds for
+// each layout of drift bits once, the predicate compares masked bits of
+// identifier with bounds, fractions are not decoded. Time window is aligned
+// to the resolution of ⟨𝒕⟩ fraction.
+func Match(spec MatchSpec) func(K) bool {
+	const tick = uint64(1) << bitsSeqDrift
+
+	var (
+		anyTime        = spec.From.IsZero() && spec.To.IsZero()
+		timeLo, timeHi = uint64(0), ^uint64(0)
+		seq            = bound{
+			mask: K{Lo: 0x3fff},
+			lo:   K{Lo: uint64(spec.SeqFrom)},
+			hi:   K{Lo: uint64(spec.SeqTo)},
+		}
+	)
+
+	if !spec.From.IsZero() {
+		timeLo = uint64(spec.From.UnixNano()) >> bitsSeqDrift << bitsSeqDrift
+	}
+	if !spec.To.IsZero() {
+		timeHi = uint64(spec.To.UnixNano()) >> bitsSeqDrift << bitsSeqDrift
+		if timeHi < tick || timeHi <= timeLo {
+			return func(K) bool { return false }
+		}
+		timeHi -= tick
+	}
+
+	// bounds of global values are indexed by drift bits, local ones follow
+	var node, window [16]bound
+	for i := range 8 {
+		d := uint64(driftZ + i)
+
+		node[i] = bound{
+			mask: makeG(0xffffffff, d, 0, 0),
+			lo:   makeG(uint64(spec.NodeFrom), d, 0, 0),
+			hi:   makeG(uint64(spec.NodeTo), d, 0, 0),
+		}
+		window[i] = masked(makeG(0, d, ^uint64(0), 0), makeG(0, d, timeLo, 0), makeG(0, d, timeHi, 0))
+
+		// local values have zero location
+		node[8+i] = bound{lo: K{Lo: uint64(spec.NodeFrom)}, hi: K{Lo: uint64(spec.NodeTo)}}
+		window[8+i] = masked(makeL(d, ^uint64(0), 0), makeL(d, timeLo, 0), makeL(d, timeHi, 0))
+	}
+
+	return func(uid K) bool {
+		i := uid.Hi >> 29 & 7
+		if uid.Hi == 0 {
+			i = 8 | uid.Lo>>61
+		}
+
+		return (spec.AnySeq || seq.has(uid)) &&
+			(anyTime || window[i].has(uid)) &&
+			(spec.AnyNode || node[i].has(uid))
+	}
+}
+
+// masked builds bound of the fraction, drift bits are excluded from the mask
+func masked(mask, lo, hi K) bound {
+	mask.Hi &= 0x1fffffff
+	if mask.Hi == 0 {
+		mask.Lo &= 0x1fffffffffffffff
+	}
+
+	return bound{
+		mask: mask,
+		lo:   K{Hi: lo.Hi & mask.Hi, Lo: lo.Lo & mask.Lo},
+		hi:   K{Hi: hi.Hi & mask.Hi, Lo: hi.Lo & mask.Lo},
+	}
+}
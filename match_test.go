@@ -0,0 +1,100 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestMatch(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	a, _ := guid.Compose(at, 0x10, 5)
+	b, _ := guid.Compose(at.Add(time.Hour), 0x20, 10)
+	d, _ := guid.Compose(at.Add(2*time.Hour), 0x30, 15)
+
+	for spec, expect := range map[guid.MatchSpec][]bool{
+		{AnyNode: true, AnySeq: true}:                                                   {true, true, true},
+		{NodeFrom: 0x15, NodeTo: 0x30, AnySeq: true}:                                    {false, true, true},
+		{SeqFrom: 1, SeqTo: 10, AnyNode: true}:                                          {true, true, false},
+		{From: at.Add(time.Hour), AnyNode: true, AnySeq: true}:                          {false, true, true},
+		{To: at.Add(time.Hour), AnyNode: true, AnySeq: true}:                            {true, false, false},
+		{To: at, From: at.Add(time.Hour), AnyNode: true}:                                {false, false, false},
+		{NodeFrom: 0x20, NodeTo: 0x20, SeqFrom: 10, SeqTo: 10}:                          {false, true, false},
+		{From: at, To: at.Add(90 * time.Minute), SeqFrom: 10, SeqTo: 20, AnyNode: true}: {false, true, false},
+	} {
+		match := guid.Match(spec)
+		it.Then(t).Should(
+			it.Seq([]bool{match(a), match(b), match(d)}).Equal(expect...),
+		)
+	}
+}
+
+func TestMatchZero(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	zero, _ := guid.Compose(at, 0, 0)
+	other, _ := guid.Compose(at, 1, 1)
+	local := guid.ToL(zero)
+
+	match := guid.Match(guid.MatchSpec{})
+	it.Then(t).Should(
+		it.True(match(zero)),
+		it.True(match(local)),
+	).ShouldNot(
+		it.True(match(other)),
+		it.True(match(guid.ToL(other))),
+	)
+}
+
+func TestMatchLayouts(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	spec := guid.MatchSpec{
+		NodeFrom: 0x100, NodeTo: 0x1ff,
+		From: at.Add(10 * time.Minute), To: at.Add(50 * time.Minute),
+		SeqFrom: 0x10, SeqTo: 0x2000,
+	}
+	match := guid.Match(spec)
+
+	from := uint64(spec.From.UnixNano()) >> 17 << 17
+	to := uint64(spec.To.UnixNano()) >> 17 << 17
+	expect := func(uid guid.K) bool {
+		return guid.Node(uid) >= 0x100 && guid.Node(uid) <= 0x1ff &&
+			guid.Time(uid) >= from && guid.Time(uid) < to &&
+			guid.Seq(uid) >= 0x10 && guid.Seq(uid) <= 0x2000
+	}
+
+	for _, drift := range []time.Duration{0, 30 * time.Second, 5 * time.Minute, time.Hour} {
+		for i := 0; i < 1000; i++ {
+			node := uint32(i * 7 % 0x300)
+			seq := uint16(i * 131 % 0x4000)
+			uid, _ := guid.Compose(at.Add(time.Duration(i)*time.Minute/16), node, seq, drift)
+
+			it.Then(t).Should(
+				it.Equal(match(uid), expect(uid)),
+				it.Equal(match(guid.ToL(uid)), expect(guid.ToL(uid))),
+				it.Equal(match(guid.WithClass(uid, 3)), expect(uid)),
+			)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"crypto/sha256"
+	"time"
+)
+
+// Merkle is a summary of k-ordered values, bucketed by ⟨𝒕⟩ prefix into leaves
+// of binary Merkle tree. The leaf summarizes its values by XOR of their hashes,
+// so values are added in any order. Replicas compare roots and reconcile
+// divergent time ranges only. The tree is sparse, empty subtrees have zero hash.
+type Merkle struct {
+	depth  uint
+	leaves map[uint64][sha256.Size]byte
+	levels []map[uint64][sha256.Size]byte
+}
+
+// MerkleRange is a time range [From, To) of divergent values
+type MerkleRange struct {
+	From, To time.Time
+}
+
+// NewMerkle creates Merkle summary of given depth (1 ≤ depth ≤ 47), the leaf
+// covers 2⁶⁴⁻ᵈᵉᵖᵗʰ nanoseconds (e.g. depth 30 gives about 17 seconds).
+func NewMerkle(depth int) *Merkle {
+	if depth < 1 || depth > 47 {
+		panic("guid: merkle depth must be within 1 and 47")
+	}
+
+	return &Merkle{
+		depth:  uint(depth),
+		leaves: map[uint64][sha256.Size]byte{},
+	}
+}
+
+// Add values to the summary
+func (m *Merkle) Add(ids ...K) {
+	for _, uid := range ids {
+		key := Time(uid) >> (64 - m.depth)
+		hash := sha256.Sum256(Bytes(uid))
+
+		leaf := m.leaves[key]
+		for i := range leaf {
+			leaf[i] ^= hash[i]
+		}
+		m.leaves[key] = leaf
+	}
+	m.levels = nil
+}
+
+// Root hash of the summary
+func (m *Merkle) Root() [sha256.Size]byte {
+	return m.tree()[0][0]
+}
+
+// Diff returns time ranges where summaries diverge. Both summaries must
+// have the same depth.
+func (m *Merkle) Diff(other *Merkle) []MerkleRange {
+	if m.depth != other.depth {
+		panic("guid: merkle summaries of different depth")
+	}
+
+	a, b := m.tree(), other.tree()
+
+	var seq []MerkleRange
+	var walk func(level uint, index uint64)
+	walk = func(level uint, index uint64) {
+		if a[level][index] == b[level][index] {
+			return
+		}
+
+		if level == m.depth {
+			width := uint64(1) << (64 - m.depth)
+			from := time.Unix(0, int64(index*width))
+			to := time.Unix(0, int64(index*width+width))
+
+			if n := len(seq); n > 0 && seq[n-1].To.Equal(from) {
+				seq[n-1].To = to
+			} else {
+				seq = append(seq, MerkleRange{From: from, To: to})
+			}
+			return
+		}
+
+		walk(level+1, index<<1)
+		walk(level+1, index<<1|1)
+	}
+	walk(0, 0)
+
+	return seq
+}
+
+// tree builds levels of the tree bottom-up from sparse leaves
+func (m *Merkle) tree() []map[uint64][sha256.Size]byte {
+	if m.levels != nil {
+		return m.levels
+	}
+
+	var zero [sha256.Size]byte
+
+	levels := make([]map[uint64][sha256.Size]byte, m.depth+1)
+	levels[m.depth] = make(map[uint64][sha256.Size]byte, len(m.leaves))
+	for key, leaf := range m.leaves {
+		if leaf != zero {
+			levels[m.depth][key] = leaf
+		}
+	}
+
+	for level := int(m.depth) - 1; level >= 0; level-- {
+		below := levels[level+1]
+		nodes := make(map[uint64][sha256.Size]byte, len(below)/2+1)
+		for key := range below {
+			parent := key >> 1
+			if _, has := nodes[parent]; has {
+				continue
+			}
+
+			l, r := below[parent<<1], below[parent<<1|1]
+			h := sha256.New()
+			h.Write(l[:])
+			h.Write(r[:])
+
+			var node [sha256.Size]byte
+			copy(node[:], h.Sum(nil))
+			nodes[parent] = node
+		}
+		levels[level] = nodes
+	}
+
+	m.levels = levels
+	return levels
+}
@@ -0,0 +1,68 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestMerkle(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	ids := make([]guid.K, 0, 100)
+	for i := 0; i < 100; i++ {
+		uid, _ := guid.Compose(at.Add(time.Duration(i)*time.Minute), 0xa, uint16(i))
+		ids = append(ids, uid)
+	}
+
+	a := guid.NewMerkle(30)
+	a.Add(ids...)
+
+	b := guid.NewMerkle(30)
+	for i := len(ids) - 1; i >= 0; i-- {
+		if i != 42 {
+			b.Add(ids[i])
+		}
+	}
+
+	it.Then(t).ShouldNot(
+		it.Equal(a.Root(), b.Root()),
+	)
+
+	diff := a.Diff(b)
+	it.Then(t).Should(
+		it.Equal(len(diff), 1),
+		it.True(!guid.EpochT(ids[42]).Before(diff[0].From)),
+		it.True(guid.EpochT(ids[42]).Before(diff[0].To)),
+	)
+
+	b.Add(ids[42])
+	it.Then(t).Should(
+		it.Equal(a.Root(), b.Root()),
+		it.Equal(len(a.Diff(b)), 0),
+	)
+
+	empty := guid.NewMerkle(30)
+	it.Then(t).Should(
+		it.Equal(len(a.Diff(empty)), 100),
+	)
+}
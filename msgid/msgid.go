@@ -0,0 +1,107 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package msgid implements a single convention of carrying k-ordered values
+// in messaging headers (NATS) and message properties (AMQP).
+//
+// Identifiers are encoded as lexicographically sortable strings, so that
+// brokers and consumers ordering by message id preserve k-order. Local values
+// are encoded as canonical local string (see guid.DeduplicationID).
+//
+//	msg := nats.NewMsg(subject)
+//	msgid.SetHeader(msg.Header, uid)
+//
+//	p := amqp.Publishing{}
+//	p.MessageId, p.CorrelationId = msgid.AMQP(uid, cause)
+package msgid
+
+import (
+	"fmt"
+
+	"github.com/fogfish/guid/v2"
+)
+
+const (
+	// HeaderMessageID is NATS header of message identity, JetStream uses it
+	// for deduplication of published messages.
+	HeaderMessageID = "Nats-Msg-Id"
+
+	// HeaderCorrelationID is NATS header of identity of causing message.
+	HeaderCorrelationID = "Correlation-Id"
+)
+
+// SetHeader sets message identity at NATS header. The nats.Header is
+// assignable to map[string][]string.
+func SetHeader(h map[string][]string, uid guid.K) {
+	h[HeaderMessageID] = []string{guid.DeduplicationID(uid)}
+}
+
+// FromHeader extracts message identity from NATS header
+func FromHeader(h map[string][]string) (guid.K, error) {
+	return get(h, HeaderMessageID)
+}
+
+// SetCorrelation sets identity of causing message at NATS header
+func SetCorrelation(h map[string][]string, cause guid.K) {
+	h[HeaderCorrelationID] = []string{guid.DeduplicationID(cause)}
+}
+
+// FromCorrelation extracts identity of causing message from NATS header
+func FromCorrelation(h map[string][]string) (guid.K, error) {
+	return get(h, HeaderCorrelationID)
+}
+
+func get(h map[string][]string, key string) (guid.K, error) {
+	val := h[key]
+	if len(val) == 0 || val[0] == "" {
+		return guid.K{}, fmt.Errorf("header %s is not defined", key)
+	}
+
+	return guid.FromString(val[0])
+}
+
+// AMQP encodes message identity and identity of causing message as values
+// of MessageId and CorrelationId properties. Nil cause is encoded as empty
+// string, which omits the property.
+func AMQP(uid, cause guid.K) (messageID, correlationID string) {
+	messageID = guid.DeduplicationID(uid)
+	if cause != guid.Nil() {
+		correlationID = guid.DeduplicationID(cause)
+	}
+	return
+}
+
+// FromAMQP decodes MessageId and CorrelationId properties. Empty
+// CorrelationId is decoded as Nil cause.
+func FromAMQP(messageID, correlationID string) (uid, cause guid.K, err error) {
+	if messageID == "" {
+		return guid.K{}, guid.K{}, fmt.Errorf("property MessageId is not defined")
+	}
+
+	if uid, err = guid.FromString(messageID); err != nil {
+		return guid.K{}, guid.K{}, err
+	}
+
+	if correlationID != "" {
+		if cause, err = guid.FromString(correlationID); err != nil {
+			return guid.K{}, guid.K{}, err
+		}
+	}
+
+	return uid, cause, nil
+}
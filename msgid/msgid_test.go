@@ -0,0 +1,113 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package msgid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/msgid"
+	"github.com/fogfish/it/v2"
+)
+
+func TestHeader(t *testing.T) {
+	clock := guid.NewClock()
+	uid, cause := guid.G(clock), guid.G(clock)
+
+	h := map[string][]string{}
+	msgid.SetHeader(h, uid)
+	msgid.SetCorrelation(h, cause)
+
+	a, erra := msgid.FromHeader(h)
+	b, errb := msgid.FromCorrelation(h)
+	_, errc := msgid.FromHeader(map[string][]string{})
+
+	it.Then(t).Should(
+		it.Equal(h[msgid.HeaderMessageID][0], guid.String(uid)),
+		it.Nil(erra),
+		it.Equal(a, uid),
+		it.Nil(errb),
+		it.Equal(b, cause),
+	).ShouldNot(
+		it.Nil(errc),
+	)
+}
+
+func TestAMQP(t *testing.T) {
+	clock := guid.NewClock()
+	uid, cause := guid.G(clock), guid.G(clock)
+
+	messageID, correlationID := msgid.AMQP(uid, cause)
+	a, b, err := msgid.FromAMQP(messageID, correlationID)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(a, uid),
+		it.Equal(b, cause),
+	)
+
+	messageID, correlationID = msgid.AMQP(uid, guid.Nil())
+	a, b, err = msgid.FromAMQP(messageID, correlationID)
+	it.Then(t).Should(
+		it.Equal(correlationID, ""),
+		it.Nil(err),
+		it.Equal(a, uid),
+		it.Equal(b, guid.Nil()),
+	)
+
+	_, _, err = msgid.FromAMQP("", "")
+	it.Then(t).ShouldNot(
+		it.Nil(err),
+	)
+}
+
+func TestOrdering(t *testing.T) {
+	clock := guid.NewClock()
+	a, b := guid.G(clock), guid.G(clock)
+
+	ma, _ := msgid.AMQP(a, guid.Nil())
+	mb, _ := msgid.AMQP(b, guid.Nil())
+	it.Then(t).Should(
+		it.Less(ma, mb),
+	)
+}
+
+func TestLocal(t *testing.T) {
+	clock := guid.NewClock()
+	uid, cause := guid.L(clock), guid.L(clock)
+
+	h := map[string][]string{}
+	msgid.SetHeader(h, uid)
+	msgid.SetCorrelation(h, cause)
+
+	a, erra := msgid.FromHeader(h)
+	b, errb := msgid.FromCorrelation(h)
+	it.Then(t).Should(
+		it.Nil(erra),
+		it.Equal(a, uid),
+		it.Nil(errb),
+		it.Equal(b, cause),
+	)
+
+	x, y, err := msgid.FromAMQP(msgid.AMQP(uid, cause))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x, uid),
+		it.Equal(y, cause),
+	)
+}
@@ -0,0 +1,166 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"iter"
+	"sort"
+	"time"
+)
+
+// OrderError describes the first violation of k-ordering in the stream of
+// identifiers: value at Index - Window is after the value at Index.
+type OrderError struct {
+	Index  int
+	Window int
+	A, B   K
+	// Measured disorder, the distance in time between violating values
+	Skew time.Duration
+}
+
+func (e *OrderError) Error() string {
+	return fmt.Sprintf("k-order violation at %d (k = %d): %s is after %s by %s", e.Index, e.Window, e.A, e.B, e.Skew)
+}
+
+// CheckOrder verifies that the stream is k-ordered for the given window:
+//
+//	𝑨[𝒊 − 𝒌] ≤ 𝑨[𝒊] for all 𝒊 such that 𝒌 ≤ 𝒊.
+//
+// It returns *OrderError for the first violating pair.
+func CheckOrder(seq iter.Seq[K], k int) error {
+	if k <= 0 {
+		return fmt.Errorf("invalid k-order window: %d", k)
+	}
+
+	ring := make([]K, k)
+	i := 0
+	for uid := range seq {
+		if i >= k {
+			if prev := ring[i%k]; After(prev, uid) {
+				return &OrderError{
+					Index:  i,
+					Window: k,
+					A:      prev,
+					B:      uid,
+					Skew:   time.Duration(int64(Time(prev) - Time(uid))),
+				}
+			}
+		}
+		ring[i%k] = uid
+		i++
+	}
+
+	return nil
+}
+
+// Stats describes disorder of the captured window of identifiers
+type Stats struct {
+	// Maximal distance of identifier from its position in sorted sequence
+	MaxDisplacement int
+	// Number of pairs 𝒊 < 𝒋 such that 𝑨[𝒊] is after 𝑨[𝒋]
+	Inversions int
+	// Effective k, the sequence is k-ordered for any window ≥ K
+	K int
+}
+
+// Disorder computes disorder statistic of the captured window of identifiers.
+// The statistic helps on tuning of drift values.
+func Disorder(ids []K) Stats {
+	if len(ids) == 0 {
+		return Stats{}
+	}
+
+	return Stats{
+		MaxDisplacement: maxDisplacement(ids),
+		Inversions:      inversions(append([]K(nil), ids...), make([]K, len(ids))),
+		K:               effectiveK(ids),
+	}
+}
+
+func maxDisplacement(ids []K) int {
+	idx := make([]int, len(ids))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return Before(ids[idx[i]], ids[idx[j]]) })
+
+	max := 0
+	for rank, pos := range idx {
+		d := pos - rank
+		if d < 0 {
+			d = -d
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// counts inversions using merge sort, ids are sorted in-place
+func inversions(ids, buf []K) int {
+	if len(ids) < 2 {
+		return 0
+	}
+
+	m := len(ids) / 2
+	n := inversions(ids[:m], buf[:m]) + inversions(ids[m:], buf[m:])
+
+	i, j, k := 0, m, 0
+	for i < m && j < len(ids) {
+		if After(ids[i], ids[j]) {
+			n += m - i
+			buf[k] = ids[j]
+			j++
+		} else {
+			buf[k] = ids[i]
+			i++
+		}
+		k++
+	}
+	k += copy(buf[k:], ids[i:m])
+	copy(buf[k:], ids[j:])
+	copy(ids, buf)
+
+	return n
+}
+
+// effective k is longest distance between inverted pair plus one
+func effectiveK(ids []K) int {
+	// prefix maximums are non-decreasing, the first preceding value after
+	// 𝑨[𝒋] is found using binary search.
+	pmax := make([]K, len(ids))
+	pmax[0] = ids[0]
+	for i := 1; i < len(ids); i++ {
+		pmax[i] = pmax[i-1]
+		if After(ids[i], pmax[i]) {
+			pmax[i] = ids[i]
+		}
+	}
+
+	k := 1
+	for j := 1; j < len(ids); j++ {
+		i := sort.Search(j, func(i int) bool { return After(pmax[i], ids[j]) })
+		if i < j && j-i+1 > k {
+			k = j - i + 1
+		}
+	}
+	return k
+}
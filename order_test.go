@@ -0,0 +1,89 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestCheckOrder(t *testing.T) {
+	c := guid.NewClock()
+	seq := []guid.K{}
+	for i := 0; i < 10; i++ {
+		seq = append(seq, guid.G(c))
+	}
+
+	it.Then(t).Should(
+		it.Nil(guid.CheckOrder(slices.Values(seq), 1)),
+		it.Nil(guid.CheckOrder(slices.Values(seq), 5)),
+	)
+
+	seq[3], seq[5] = seq[5], seq[3]
+
+	var e *guid.OrderError
+	err := guid.CheckOrder(slices.Values(seq), 1)
+	it.Then(t).Should(
+		it.True(errors.As(err, &e)),
+		it.Equal(e.Index, 4),
+		it.Equal(e.A, seq[3]),
+		it.Equal(e.B, seq[4]),
+		it.Nil(guid.CheckOrder(slices.Values(seq), 3)),
+	)
+
+	err = guid.CheckOrder(slices.Values(seq), 2)
+	it.Then(t).Should(
+		it.True(errors.As(err, &e)),
+		it.Equal(e.Index, 5),
+		it.Equal(e.Window, 2),
+	)
+
+	it.Then(t).ShouldNot(
+		it.Nil(guid.CheckOrder(slices.Values(seq), 0)),
+	)
+}
+
+func TestDisorder(t *testing.T) {
+	c := guid.NewClock()
+	seq := []guid.K{}
+	for i := 0; i < 10; i++ {
+		seq = append(seq, guid.G(c))
+	}
+
+	it.Then(t).Should(
+		it.Equal(guid.Disorder(seq), guid.Stats{K: 1}),
+		it.Equal(guid.Disorder(nil), guid.Stats{}),
+	)
+
+	// 0 1 2 5 4 3 6 7 8 9
+	seq[3], seq[5] = seq[5], seq[3]
+	it.Then(t).Should(
+		it.Equal(guid.Disorder(seq), guid.Stats{MaxDisplacement: 2, Inversions: 3, K: 3}),
+	)
+
+	// 9 1 2 5 4 3 6 7 8 0
+	seq[0], seq[9] = seq[9], seq[0]
+	it.Then(t).Should(
+		it.Equal(guid.Disorder(seq), guid.Stats{MaxDisplacement: 9, Inversions: 20, K: 10}),
+	)
+}
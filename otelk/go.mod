@@ -0,0 +1,11 @@
+module github.com/fogfish/guid/otelk
+
+go 1.23
+
+require (
+	github.com/fogfish/guid/v2 v2.0.0
+	github.com/fogfish/it/v2 v2.0.1
+	go.opentelemetry.io/otel v1.34.0
+)
+
+replace github.com/fogfish/guid/v2 => ../
@@ -0,0 +1,47 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package otelk implements OpenTelemetry adapter for k-ordered values.
+package otelk
+
+import (
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SpanAttributes emits k-ordered value with decoded fractions as span
+// attributes id, id.time, id.node and id.seq. The node attribute allows
+// tracing backends to filter spans by producer.
+//
+//	span.SetAttributes(otelk.SpanAttributes(uid)...)
+func SpanAttributes(uid guid.K) []attribute.KeyValue {
+	return Attributes("id", uid)
+}
+
+// Attributes emits k-ordered value with decoded fractions as attributes
+// under the given key, e.g. key, key.time, key.node and key.seq.
+func Attributes(key string, uid guid.K) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(key, guid.String(uid)),
+		attribute.String(key+".time", guid.EpochT(uid).UTC().Format(time.RFC3339Nano)),
+		attribute.Int64(key+".node", int64(guid.Node(uid))),
+		attribute.Int64(key+".seq", int64(guid.Seq(uid))),
+	}
+}
@@ -0,0 +1,58 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package otelk_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/otelk"
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestSpanAttributes(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+	set := attribute.NewSet(otelk.SpanAttributes(uid)...)
+
+	id, _ := set.Value("id")
+	ts, _ := set.Value("id.time")
+	node, _ := set.Value("id.node")
+	seq, _ := set.Value("id.seq")
+
+	it.Then(t).Should(
+		it.Equal(set.Len(), 4),
+		it.Equal(id.AsString(), guid.String(uid)),
+		it.Equal(ts.AsString(), guid.EpochT(uid).UTC().Format(time.RFC3339Nano)),
+		it.Equal(node.AsInt64(), 0xa),
+		it.Equal(seq.AsInt64(), int64(guid.Seq(uid))),
+	)
+}
+
+func TestAttributes(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+	set := attribute.NewSet(otelk.Attributes("order", uid)...)
+
+	node, ok := set.Value("order.node")
+	it.Then(t).Should(
+		it.True(ok),
+		it.Equal(node.AsInt64(), 0xa),
+	)
+}
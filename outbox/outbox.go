@@ -0,0 +1,104 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package outbox implements transactional outbox pattern for rows keyed by
+// k-ordered values. The application writes rows within business transaction,
+// the poller relays them strictly in k-order, tracking the watermark of
+// delivered rows.
+package outbox
+
+import (
+	"context"
+	"slices"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// Row of transactional outbox
+type Row struct {
+	ID      guid.K
+	Payload []byte
+}
+
+// Store is persistence of outbox rows
+type Store interface {
+	// Fetch returns up to n rows with the smallest identifiers after the
+	// watermark (e.g. WHERE id > $1 ORDER BY id LIMIT $2)
+	Fetch(ctx context.Context, after guid.K, n int) ([]Row, error)
+}
+
+// Poller reads rows from the store strictly in k-order
+type Poller struct {
+	store     Store
+	batch     int
+	watermark guid.K
+}
+
+// NewPoller creates poller of rows after the watermark (e.g. persisted from
+// previous run), guid.Nil polls from the beginning.
+func NewPoller(store Store, batch int, watermark guid.K) *Poller {
+	if batch <= 0 {
+		panic("outbox: batch size must be positive")
+	}
+
+	return &Poller{store: store, batch: batch, watermark: watermark}
+}
+
+// Poll returns next batch of rows after the watermark, ordered and without
+// duplicates. The watermark is advanced by Commit once rows are delivered.
+func (p *Poller) Poll(ctx context.Context) ([]Row, error) {
+	rows, err := p.store.Fetch(ctx, p.watermark, p.batch)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(rows, func(a, b Row) int {
+		switch {
+		case guid.Before(a.ID, b.ID):
+			return -1
+		case guid.After(a.ID, b.ID):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	seq := rows[:0]
+	last := p.watermark
+	for _, row := range rows {
+		if guid.After(row.ID, last) {
+			seq = append(seq, row)
+			last = row.ID
+		}
+	}
+
+	return seq, nil
+}
+
+// Commit advances the watermark to the delivered row. The watermark never
+// moves backward.
+func (p *Poller) Commit(uid guid.K) {
+	if guid.After(uid, p.watermark) {
+		p.watermark = uid
+	}
+}
+
+// Watermark returns identifier of the last delivered row
+func (p *Poller) Watermark() guid.K {
+	return p.watermark
+}
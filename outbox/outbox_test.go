@@ -0,0 +1,70 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package outbox_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/outbox"
+	"github.com/fogfish/it/v2"
+)
+
+// store returns first rows after watermark in reverse order
+type store []outbox.Row
+
+func (s store) Fetch(ctx context.Context, after guid.K, n int) ([]outbox.Row, error) {
+	rows := []outbox.Row{}
+	for i := 0; i < len(s) && len(rows) < n; i++ {
+		if guid.After(s[i].ID, after) {
+			rows = append(rows, s[i])
+		}
+	}
+	slices.Reverse(rows)
+	return rows, nil
+}
+
+func TestPoller(t *testing.T) {
+	c := guid.NewClock()
+	a, b, d := guid.G(c), guid.G(c), guid.G(c)
+	s := store{{ID: a}, {ID: b}, {ID: b}, {ID: d}}
+
+	p := outbox.NewPoller(s, 3, guid.Nil())
+
+	rows, err := p.Poll(context.Background())
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(rows), 2),
+		it.Equal(rows[0].ID, a),
+		it.Equal(rows[1].ID, b),
+	)
+
+	p.Commit(b)
+	p.Commit(a)
+
+	rows, err = p.Poll(context.Background())
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(rows), 1),
+		it.Equal(rows[0].ID, d),
+		it.Equal(p.Watermark(), b),
+	)
+}
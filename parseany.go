@@ -0,0 +1,140 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Format is name of text encoding of k-ordered values
+type Format string
+
+// Built-in text encodings
+const (
+	FormatString Format = "string" // lexicographically sortable, see String
+	FormatBase62 Format = "base62" // see Base62
+	FormatHex    Format = "hex"    // hex of binary form, see Bytes
+	FormatUUID   Format = "uuid"   // 128-bit UUID text form, Hi ‖ Lo
+)
+
+// ParseAny decodes k-ordered value from any supported text encoding and
+// reports the matched one. Only canonical values are accepted (see Canonical),
+// empty input and Nil value are rejected.
+// Short alphanumeric values are ambiguous (e.g. 16 chars are valid string and
+// base62), the value with plausible ⟨𝒕⟩ timestamp (since 2000 and not later
+// than a year ahead) is preferred, otherwise encodings are tried in the order
+// string, base62, hex, UUID and encodings registered by RegisterFormat.
+func ParseAny(s string) (K, Format, error) {
+	var (
+		uid    K
+		format Format
+		found  bool
+	)
+
+	for _, f := range registeredFormats() {
+		x, err := Strict(f.decode(s))
+		if err != nil || x == Nil() {
+			continue
+		}
+
+		if isPlausible(x) {
+			return x, f.format, nil
+		}
+
+		if !found {
+			uid, format, found = x, f.format, true
+		}
+	}
+
+	if !found {
+		return K{}, "", fmt.Errorf("malformed k-order number: %v", s)
+	}
+	return uid, format, nil
+}
+
+// plausible window of ⟨𝒕⟩ timestamps, since 2000-01-01
+const plausibleSince = 946684800 * uint64(time.Second)
+
+func isPlausible(uid K) bool {
+	t := Time(uid)
+	return t >= plausibleSince && t <= uint64(time.Now().Add(365*24*time.Hour).UnixNano())
+}
+
+// fromString64 decodes lexicographically sortable string, characters are
+// validated strictly
+func fromString64(s string) (K, error) {
+	if !isAlphabet64(s) {
+		return K{}, fmt.Errorf("malformed k-order number: %v", s)
+	}
+	return FromString(s)
+}
+
+// fromHex decodes hex of binary form
+func fromHex(s string) (K, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return K{}, fmt.Errorf("malformed k-order number: %v", s)
+	}
+	return FromBytes(b)
+}
+
+// isAlphabet64 checks characters of lexicographically sortable string
+func isAlphabet64(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if decoder64(s[i]) > 0x3f && !(i == 0 && s[i] == '*') {
+			return false
+		}
+	}
+	return true
+}
+
+// formatUUID encodes k-ordered value as 128-bit UUID text form, class and
+// delete-marker bits are dropped
+func formatUUID(uid K) string {
+	uid.Hi &= 0xffffffff
+
+	var b [16]byte
+	for i := 0; i < 8; i++ {
+		b[i] = byte(uid.Hi >> (56 - 8*i))
+		b[8+i] = byte(uid.Lo >> (56 - 8*i))
+	}
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// fromUUID decodes k-ordered value from 128-bit UUID text form
+func fromUUID(s string) (K, error) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return K{}, fmt.Errorf("malformed k-order number: %v", s)
+	}
+
+	b, err := hex.DecodeString(s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36])
+	if err != nil {
+		return K{}, fmt.Errorf("malformed k-order number: %v", s)
+	}
+
+	var uid K
+	for i := 0; i < 8; i++ {
+		uid.Hi = uid.Hi<<8 | uint64(b[i])
+		uid.Lo = uid.Lo<<8 | uint64(b[8+i])
+	}
+	return uid, nil
+}
@@ -0,0 +1,97 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestParseAny(t *testing.T) {
+	for _, v := range guid.TestVectors() {
+		// values with implausible timestamps are ambiguous
+		if !v.Global || v.UnixNano < 946684800000000000 || v.UnixNano > time.Now().UnixNano() {
+			continue
+		}
+
+		for val, format := range map[string]guid.Format{
+			v.String: guid.FormatString,
+			v.Base62: guid.FormatBase62,
+			v.Hex:    guid.FormatHex,
+		} {
+			uid, f, err := guid.ParseAny(val)
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equal(f, format),
+				it.Equal(hex.EncodeToString(guid.Bytes(uid)), v.Hex),
+			)
+		}
+	}
+
+	clock := guid.NewClock()
+	for _, uid := range []guid.K{guid.G(clock), guid.L(clock)} {
+		str := guid.String(uid)
+		if uid.Hi == 0 {
+			str = guid.StringLocal(uid)
+		}
+
+		for val, format := range map[string]guid.Format{
+			str:                                 guid.FormatString,
+			guid.Base62(uid):                    guid.FormatBase62,
+			hex.EncodeToString(guid.Bytes(uid)): guid.FormatHex,
+		} {
+			x, f, err := guid.ParseAny(val)
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equal(f, format),
+				it.Equal(x, uid),
+			)
+		}
+	}
+
+	uid, f, err := guid.ParseAny("00000000-82fa-9bf0-0000-00a2f21e8005")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(f, guid.FormatUUID),
+		it.Equal(hex.EncodeToString(guid.Bytes(uid)), "82fa9bf0000000a2f21e8005"),
+	)
+
+	x, _ := guid.EncodeFormat(guid.WithClass(uid, 3), guid.FormatUUID)
+	it.Then(t).Should(
+		it.Equal(x, "00000000-82fa-9bf0-0000-00a2f21e8005"),
+	)
+
+	for _, val := range []string{
+		"",
+		"!!!",
+		"00000000-82fa-9bf0-0000-00a2f21e800x",
+		"00000000-0000-0000-0000-000000000000",
+		"zzzzzzzzzzzzzzzzzzzzzzzz",
+		"000000000000000000000000",
+	} {
+		_, _, err := guid.ParseAny(val)
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	}
+}
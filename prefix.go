@@ -0,0 +1,44 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "time"
+
+// PrefixForWindow computes the longest prefix of lexicographically sortable
+// strings (see String) shared by all global identifiers allocated within the
+// time window [t, t + window) with the given drift. The prefix scan of key-value
+// stores returns superset of identifiers in the window, the application
+// filters them by time. It returns false when identifiers share no prefix.
+func PrefixForWindow(t time.Time, window time.Duration, drift ...time.Duration) (prefix string, ok bool) {
+	if window <= 0 {
+		return "", false
+	}
+
+	d := driftInBits(drift)
+	lo := makeG(0, d, uint64(t.UnixNano()), 0)
+	hi := makeG(0xffffffff, d, uint64(t.Add(window-1).UnixNano()), 0x3fff)
+
+	a, b := String(lo), String(hi)
+	n := 0
+	for n < len(a) && a[n] == b[n] {
+		n++
+	}
+
+	return a[:n], n > 0
+}
@@ -0,0 +1,57 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestPrefixForWindow(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	prefix, ok := guid.PrefixForWindow(at, time.Hour)
+	it.Then(t).Should(
+		it.True(ok),
+		it.True(len(prefix) > 1),
+	)
+
+	for _, tt := range []time.Duration{0, time.Minute, 30 * time.Minute, time.Hour - time.Millisecond} {
+		for _, node := range []uint32{0, 0xa, 0xffffffff} {
+			uid, _ := guid.Compose(at.Add(tt), node, 0x3fff)
+			it.Then(t).Should(
+				it.True(strings.HasPrefix(uid.String(), prefix)),
+			)
+		}
+	}
+
+	long, _ := guid.PrefixForWindow(at, time.Minute)
+	it.Then(t).Should(
+		it.True(strings.HasPrefix(long, prefix)),
+	)
+
+	_, ok = guid.PrefixForWindow(at, 0)
+	it.Then(t).ShouldNot(
+		it.True(ok),
+	)
+}
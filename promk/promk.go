@@ -0,0 +1,58 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package promk implements Prometheus adapter for k-ordered values.
+package promk
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Exemplar emits k-ordered value with its generating node and time as
+// exemplar labels. The labels fit into prometheus.ExemplarMaxRunes.
+func Exemplar(uid guid.K) prometheus.Labels {
+	return prometheus.Labels{
+		"id":   guid.String(uid),
+		"node": strconv.FormatUint(guid.Node(uid), 10),
+		"time": guid.EpochT(uid).UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// Add increments counter by value, attaching k-ordered value as exemplar.
+// The counter is incremented without exemplar if it is not supported.
+func Add(c prometheus.Counter, v float64, uid guid.K) {
+	if e, ok := c.(prometheus.ExemplarAdder); ok {
+		e.AddWithExemplar(v, Exemplar(uid))
+		return
+	}
+	c.Add(v)
+}
+
+// Observe records value, attaching k-ordered value as exemplar.
+// The value is observed without exemplar if it is not supported.
+func Observe(o prometheus.Observer, v float64, uid guid.K) {
+	if e, ok := o.(prometheus.ExemplarObserver); ok {
+		e.ObserveWithExemplar(v, Exemplar(uid))
+		return
+	}
+	o.Observe(v)
+}
@@ -0,0 +1,86 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package promk_test
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/fogfish/guid/promk"
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestExemplar(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+	labels := promk.Exemplar(uid)
+
+	runes := 0
+	for k, v := range labels {
+		runes += utf8.RuneCountInString(k) + utf8.RuneCountInString(v)
+	}
+
+	it.Then(t).Should(
+		it.Equal(labels["id"], guid.String(uid)),
+		it.Equal(labels["node"], "10"),
+		it.Less(runes, prometheus.ExemplarMaxRunes+1),
+	)
+}
+
+func TestAdd(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+	c := prometheus.NewCounter(prometheus.CounterOpts{Name: "test"})
+
+	promk.Add(c, 1, uid)
+
+	var m dto.Metric
+	err := c.Write(&m)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(m.Counter.GetValue(), 1.0),
+		it.Equal(label(m.Counter.Exemplar, "id"), guid.String(uid)),
+		it.Equal(label(m.Counter.Exemplar, "node"), "10"),
+	)
+}
+
+func TestObserve(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test", Buckets: []float64{1}})
+
+	promk.Observe(h, 0.5, uid)
+
+	var m dto.Metric
+	err := h.Write(&m)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(m.Histogram.GetSampleCount(), 1),
+		it.Equal(len(m.Histogram.Bucket[0].Exemplar.Label), 3),
+	)
+}
+
+func label(e *dto.Exemplar, name string) string {
+	for _, l := range e.Label {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "sync/atomic"
+
+// local values are promoted by text and JSON encoding
+var promoteLocal atomic.Bool
+
+// SetPromoteOnMarshal configures package-level encoding of local values
+// by MarshalJSON, MarshalText and derived text forms (e.g. Unbind).
+// Local values are encoded as canonical local string by default (see
+// StringLocal), which is distinguishable from global one by its length.
+// Enabled promotion emits legacy '*'-prefixed global value using ⟨𝒍⟩ fraction
+// of default clock, which stamps the node of current process onto foreign
+// local values. The promotion is disabled by default.
+func SetPromoteOnMarshal(enabled bool) {
+	promoteLocal.Store(enabled)
+}
+
+// appendFormat appends formatString
+func appendFormat(dst []byte, uid K) []byte {
+	switch {
+	case uid == Nil():
+		return dst
+	case uid.Hi == 0 && promoteLocal.Load():
+		dst = append(dst, '*')
+		return appendString(dst, FromL(Default(), uid))
+	case uid.Hi == 0:
+		return appendLocal(dst, uid)
+	default:
+		return appendString(dst, uid)
+	}
+}
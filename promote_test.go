@@ -0,0 +1,65 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestSetPromoteOnMarshal(t *testing.T) {
+	foreign := guid.NewClock(guid.WithNodeID(0xa))
+	l := guid.L(foreign)
+
+	b, _ := json.Marshal(l)
+	it.Then(t).Should(
+		it.Equal(string(b), `"`+guid.StringLocal(l)+`"`),
+	)
+
+	var x guid.K
+	err := json.Unmarshal(b, &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x, l),
+	)
+
+	guid.SetPromoteOnMarshal(true)
+	defer guid.SetPromoteOnMarshal(false)
+
+	b, _ = json.Marshal(l)
+	it.Then(t).Should(
+		it.Equal(string(b), `"*`+guid.String(guid.FromL(guid.Default(), l))+`"`),
+	)
+
+	err = json.Unmarshal(b, &x)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x, l),
+	)
+
+	// global values are not affected
+	g := guid.G(foreign)
+	b, _ = json.Marshal(g)
+	it.Then(t).Should(
+		it.Equal(string(b), `"`+guid.String(g)+`"`),
+	)
+}
@@ -0,0 +1,57 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sync/atomic"
+)
+
+// salt of hash used by redaction
+var redactSalt atomic.Pointer[[]byte]
+
+// SetRedactSalt configures package-level salt used by Redact to hash
+// ⟨𝒍⟩ and ⟨𝒔⟩ fractions. Application configures the salt once at startup.
+func SetRedactSalt(salt []byte) {
+	b := make([]byte, len(salt))
+	copy(b, salt)
+	redactSalt.Store(&b)
+}
+
+// Redact encodes k-ordered value to a form suitable for logs where full
+// identifiers are considered sensitive. It prints ⟨𝒕⟩ timestamp with
+// minute precision and salted hash of ⟨𝒍⟩ and ⟨𝒔⟩ fractions.
+//
+//	2024-06-01T12:03Z#ab3f
+func Redact(uid K) string {
+	var b [12]byte
+	binary.BigEndian.PutUint64(b[0:8], Node(uid))
+	binary.BigEndian.PutUint32(b[8:12], uint32(Seq(uid)))
+
+	h := sha256.New()
+	if salt := redactSalt.Load(); salt != nil {
+		h.Write(*salt)
+	}
+	h.Write(b[:])
+	hash := h.Sum(nil)
+
+	return EpochT(uid).UTC().Format("2006-01-02T15:04Z") + "#" + hex.EncodeToString(hash[:2])
+}
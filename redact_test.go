@@ -0,0 +1,51 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestRedact(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 3, 10, 0, time.UTC)
+	c := guid.NewClock(
+		guid.WithNodeID(0xfedcba98),
+		guid.WithClock(func() uint64 { return uint64(at.UnixNano()) }),
+	)
+	a := guid.G(c)
+
+	x := guid.Redact(a)
+	guid.SetRedactSalt([]byte("salt"))
+	y := guid.Redact(a)
+	guid.SetRedactSalt(nil)
+
+	it.Then(t).Should(
+		it.String(x).HavePrefix("2024-06-01T12:03Z#"),
+		it.String(y).HavePrefix("2024-06-01T12:03Z#"),
+		it.Equal(len(x), len("2024-06-01T12:03Z#ab3f")),
+		it.Equal(guid.Redact(a), x),
+	).ShouldNot(
+		it.Equal(x, y),
+		it.String(x).Contain(guid.String(a)),
+	)
+}
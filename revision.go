@@ -0,0 +1,69 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "time"
+
+// NextRevision produces revision identifier of the entity, which strictly
+// follows the previous revision in raw order (see Before), so that it is
+// usable as optimistic-concurrency token. The revision keeps the form (local
+// or global) and drift of the previous one, Nil starts global revision chain.
+// If the clock lags behind previous revision (e.g. revision is produced by
+// other node or clock is skewed), the revision keeps ⟨𝒍⟩ and ⟨𝒔⟩ fractions
+// drawn from the clock and the ⟨𝒕⟩ fraction of previous revision, which is
+// advanced by a tick when it is required to follow the previous revision.
+func NextRevision(prev K, clock Chronos) K {
+	if prev == Nil() {
+		return G(clock)
+	}
+
+	drift := driftStepOf(driftOf(prev))
+	next := G(clock, drift)
+	if prev.Hi == 0 {
+		next = L(clock, drift)
+	}
+
+	if !Before(prev, next) {
+		return successorOf(prev, next)
+	}
+	return next
+}
+
+// successorOf builds the value, which follows prev, from ⟨𝒍⟩ and ⟨𝒔⟩
+// fractions of next
+func successorOf(prev, next K) K {
+	d := driftOf(prev)
+	if prev.Hi == 0 {
+		return makeL(d, Time(prev)+1<<bitsSeqDrift, Seq(next))
+	}
+
+	uid := makeG(Node(next), d, Time(prev), Seq(next))
+	if !Before(prev, uid) {
+		// ⟨𝒕⟩ bits above ⟨𝒍⟩ fraction are advanced
+		tick := uint64(1) << (layouts[(d-driftZ)&7].d + bitsSeqDrift)
+		uid = makeG(Node(next), d, Time(prev)&^(tick-1)+tick, Seq(next))
+	}
+	return uid
+}
+
+// RevisionDistance measures how far apart revisions are by ⟨𝒕⟩ timestamps,
+// it is negative if revision b precedes a.
+func RevisionDistance(a, b K) time.Duration {
+	return time.Duration(int64(Time(b) - Time(a)))
+}
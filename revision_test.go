@@ -0,0 +1,95 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestNextRevision(t *testing.T) {
+	clock := guid.NewClock(guid.WithNodeID(0xa))
+
+	prev := guid.NextRevision(guid.Nil(), clock)
+	for i := 0; i < 1000; i++ {
+		next := guid.NextRevision(prev, clock)
+		it.Then(t).Should(
+			it.True(guid.Before(prev, next)),
+		)
+		prev = next
+	}
+
+	local := guid.NextRevision(guid.L(clock), clock)
+	it.Then(t).Should(
+		it.Equal(local.Hi, 0),
+		it.Equal(guid.Node(prev), 0xa),
+	)
+}
+
+func TestNextRevisionLagging(t *testing.T) {
+	ahead := guid.NewClock(guid.WithNodeID(0xa))
+	behind := guid.NewClock(
+		guid.WithNodeID(0xa),
+		guid.WithClock(func() uint64 { return uint64(time.Now().Add(-time.Hour).UnixNano()) }),
+	)
+
+	for _, prev := range []guid.K{guid.G(ahead), guid.L(ahead)} {
+		next := guid.NextRevision(prev, behind)
+		it.Then(t).Should(
+			it.True(guid.Before(prev, next)),
+			it.True(guid.Canonical(next)),
+			it.Equal(next.Hi == 0, prev.Hi == 0),
+		).ShouldNot(
+			it.True(guid.IsTombstoneOf(next, prev)),
+			it.Equal(next, guid.TombstoneOf(prev)),
+		)
+	}
+}
+
+func TestNextRevisionForeign(t *testing.T) {
+	ahead := guid.NewClock(guid.WithNodeID(0xb))
+	behind := guid.NewClock(
+		guid.WithNodeID(0xa),
+		guid.WithClock(func() uint64 { return uint64(time.Now().Add(-time.Hour).UnixNano()) }),
+	)
+
+	prev := guid.G(ahead)
+	next := guid.NextRevision(prev, behind)
+	peer := guid.G(ahead)
+	it.Then(t).Should(
+		it.True(guid.Before(prev, next)),
+		it.Equal(guid.Node(next), 0xa),
+	).ShouldNot(
+		it.Equal(next, peer),
+	)
+}
+
+func TestRevisionDistance(t *testing.T) {
+	a := guid.FromT(time.Unix(100, 0))
+	b := guid.FromT(time.Unix(160, 0))
+
+	d := guid.RevisionDistance(a, b)
+	it.Then(t).Should(
+		it.True(d > 60*time.Second-time.Millisecond && d < 60*time.Second+time.Millisecond),
+		it.Equal(guid.RevisionDistance(b, a), -d),
+	)
+}
@@ -0,0 +1,98 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KeyOpt configures layout of object key (see S3Key)
+type KeyOpt func(*keyLayout)
+
+type keyLayout struct {
+	date    bool
+	entropy int
+}
+
+// WithDatePartition places yyyy/mm/dd/ partition of ⟨𝒕⟩ timestamp (UTC)
+// after prefix parts.
+func WithDatePartition() KeyOpt {
+	return func(layout *keyLayout) { layout.date = true }
+}
+
+// WithEntropyPrefix places n (1 ≤ n ≤ 16) hex chars in front of the key,
+// it spreads keys across S3 partitions. The entropy is derived from ⟨𝒕⟩
+// and ⟨𝒔⟩ fractions, local values share entropy with their global forms.
+func WithEntropyPrefix(n int) KeyOpt {
+	if n < 1 || n > 16 {
+		panic("guid: entropy prefix must be 1 to 16 chars")
+	}
+	return func(layout *keyLayout) { layout.entropy = n }
+}
+
+// S3Key builds object key from prefix parts and k-ordered value, partitions
+// are derived from the identifier itself instead of another clock read
+//
+//	[entropy/]part/.../[yyyy/mm/dd/]id
+//
+// The identifier is encoded as DeduplicationID, so that the key is stable
+// across processes.
+func S3Key(prefixParts []string, uid K, opts ...KeyOpt) string {
+	var layout keyLayout
+	for _, opt := range opts {
+		opt(&layout)
+	}
+
+	var sb strings.Builder
+	if layout.entropy > 0 {
+		x := mix64(Time(uid)>>bitsSeqDrift<<bitsSeq | Seq(uid))
+		sb.WriteString(fmt.Sprintf("%016x", x)[:layout.entropy])
+		sb.WriteByte('/')
+	}
+
+	for _, part := range prefixParts {
+		part = strings.Trim(part, "/")
+		if part != "" {
+			sb.WriteString(part)
+			sb.WriteByte('/')
+		}
+	}
+
+	if layout.date {
+		sb.WriteString(PartitionPath(uid, "2006/01/02/", time.UTC))
+	}
+
+	sb.WriteString(DeduplicationID(uid))
+	return sb.String()
+}
+
+// PartitionPath formats ⟨𝒕⟩ timestamp of k-ordered value using Go time layout
+// in the given zone, e.g. Hive-style partition aligned with business day
+//
+//	guid.PartitionPath(uid, "dt=2006-01-02/hr=15", loc)
+//
+// Nil location is UTC.
+func PartitionPath(uid K, layout string, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return EpochT(uid).In(loc).Format(layout)
+}
@@ -0,0 +1,66 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestS3Key(t *testing.T) {
+	clock := guid.NewClock(guid.WithNodeID(0xa))
+	uid := guid.G(clock)
+	id := guid.DeduplicationID(uid)
+	date := guid.EpochT(uid).UTC().Format("2006/01/02")
+
+	it.Then(t).Should(
+		it.Equal(guid.S3Key(nil, uid), id),
+		it.Equal(guid.S3Key([]string{"lake/", "events"}, uid), "lake/events/"+id),
+		it.Equal(guid.S3Key([]string{"events"}, uid, guid.WithDatePartition()), "events/"+date+"/"+id),
+	)
+
+	key := guid.S3Key([]string{"events"}, uid, guid.WithEntropyPrefix(4))
+	seq := strings.Split(key, "/")
+	it.Then(t).Should(
+		it.Equal(len(seq), 3),
+		it.Equal(len(seq[0]), 4),
+		it.Equal(seq[1], "events"),
+		it.Equal(seq[2], id),
+		it.Equal(
+			seq[0],
+			strings.Split(guid.S3Key(nil, guid.ToL(uid), guid.WithEntropyPrefix(4)), "/")[0],
+		),
+	)
+}
+
+func TestPartitionPath(t *testing.T) {
+	// 2024-01-01T23:30:00Z
+	uid := guid.G(guid.NewClock(guid.WithClock(func() uint64 { return 1704151800000000000 })))
+	tokyo := time.FixedZone("JST", 9*3600)
+
+	it.Then(t).Should(
+		it.Equal(guid.PartitionPath(uid, "dt=2006-01-02/hr=15", nil), "dt=2024-01-01/hr=23"),
+		it.Equal(guid.PartitionPath(uid, "dt=2006-01-02/hr=15", time.UTC), "dt=2024-01-01/hr=23"),
+		it.Equal(guid.PartitionPath(uid, "dt=2006-01-02/hr=15", tokyo), "dt=2024-01-02/hr=08"),
+	)
+}
@@ -0,0 +1,75 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"math"
+	"math/rand"
+)
+
+// SampledBy deterministically samples k-ordered values with the given rate
+// (0 ≤ rate ≤ 1). The decision is made by hashing ⟨𝒍⟩ and ⟨𝒔⟩ fractions,
+// so that distributed components keep or drop the same event consistently
+// without coordination.
+func SampledBy(uid K, rate float64) bool {
+	switch {
+	case rate <= 0:
+		return false
+	case rate >= 1:
+		return true
+	}
+
+	return mix64(Node(uid)<<bitsSeq|Seq(uid)) < uint64(rate*math.MaxUint64)
+}
+
+// splitmix64 finalizer
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// LShard assigns k-ordered value to one of n shards using ⟨𝒕⟩ and ⟨𝒔⟩
+// fractions only, so that local values are sharded consistently with their
+// global forms. The salt defines independent shard assignments.
+//
+// The assignment is stable across releases:
+//
+//	x = ⟨𝒕⟩ >> 17 << 14 | ⟨𝒔⟩
+//	shard = splitmix64(x ⊕ splitmix64(salt)) mod n
+func LShard(uid K, n int, salt uint64) int {
+	if n <= 0 {
+		panic("guid: number of shards must be positive")
+	}
+
+	x := Time(uid)>>bitsSeqDrift<<bitsSeq | Seq(uid)
+	return int(mix64(x^mix64(salt)) % uint64(n))
+}
+
+// RandSource derives deterministic pseudo-random generator from ⟨𝒍⟩ and ⟨𝒔⟩
+// fractions of k-ordered value, so that per-event randomized behavior (jitter,
+// sampling, shuffling) is reproducible given the identifier.
+//
+//	rand.New(guid.RandSource(uid)).Intn(100)
+func RandSource(uid K) rand.Source {
+	return rand.NewSource(int64(mix64(Node(uid)<<bitsSeq | Seq(uid))))
+}
@@ -0,0 +1,88 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestSampledBy(t *testing.T) {
+	c := guid.NewClock()
+
+	n := 0
+	for i := 0; i < 10000; i++ {
+		uid := guid.G(c)
+		if guid.SampledBy(uid, 0.1) {
+			n++
+		}
+
+		it.Then(t).Should(
+			it.Equal(guid.SampledBy(uid, 0.1), guid.SampledBy(uid, 0.1)),
+			it.True(guid.SampledBy(uid, 1)),
+		).ShouldNot(
+			it.True(guid.SampledBy(uid, 0)),
+		)
+	}
+
+	it.Then(t).Should(
+		it.True(n > 800 && n < 1200),
+	)
+}
+
+func TestLShard(t *testing.T) {
+	c := guid.NewClock()
+	hist := make([]int, 4)
+
+	for i := 0; i < 4000; i++ {
+		g := guid.G(c)
+		shard := guid.LShard(g, 4, 42)
+		hist[shard]++
+
+		it.Then(t).Should(
+			it.Equal(guid.LShard(guid.ToL(g), 4, 42), shard),
+		)
+	}
+
+	for _, n := range hist {
+		it.Then(t).Should(
+			it.True(n > 800 && n < 1200),
+		)
+	}
+
+	// stability of the assignment
+	uid := guid.K{Lo: 0x6000000000000001}
+	it.Then(t).Should(
+		it.Equal(guid.LShard(uid, 1000, 0), 789),
+	)
+}
+
+func TestRandSource(t *testing.T) {
+	c := guid.NewClock(guid.WithNodeID(0xa))
+	a, b := guid.G(c), guid.G(c)
+
+	it.Then(t).Should(
+		it.Equal(rand.New(guid.RandSource(a)).Int63(), rand.New(guid.RandSource(a)).Int63()),
+	).ShouldNot(
+		it.Equal(rand.New(guid.RandSource(a)).Int63(), rand.New(guid.RandSource(b)).Int63()),
+	)
+}
@@ -0,0 +1,57 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "fmt"
+
+// Versions of identity schema
+const (
+	// SchemaUnknown is not the k-ordered value of known layout (e.g. Nil)
+	// or the value of future layout.
+	SchemaUnknown = 0
+
+	// SchemaV1 is the current layout: drift table of 7 steps from 68 seconds
+	// to 73 minutes and unix epoch.
+	SchemaV1 = 1
+)
+
+// SchemaVersion detects version of identity schema at decode time. The layout
+// does not carry explicit version field, instead the zero ⟨𝒅⟩ drift never
+// allocated by SchemaV1 escapes future layouts (e.g. different drift table,
+// epoch), so that they coexist with current identifiers in one keyspace.
+func SchemaVersion(uid K) int {
+	if driftOf(uid) == driftZ {
+		return SchemaUnknown
+	}
+
+	return SchemaV1
+}
+
+// Upgrade converts k-ordered value to the target version of identity schema.
+// Values of the target version are returned as is.
+func Upgrade(uid K, version int) (K, error) {
+	switch v := SchemaVersion(uid); {
+	case v == version:
+		return uid, nil
+	case v == SchemaUnknown:
+		return K{}, fmt.Errorf("malformed k-order number: %v", uid)
+	default:
+		return K{}, fmt.Errorf("no upgrade of schema v%d to v%d", v, version)
+	}
+}
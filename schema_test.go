@@ -0,0 +1,56 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	g := guid.G(guid.Clock, 30*time.Second)
+	l := guid.L(guid.Clock, time.Hour)
+
+	it.Then(t).Should(
+		it.Equal(guid.SchemaVersion(g), guid.SchemaV1),
+		it.Equal(guid.SchemaVersion(l), guid.SchemaV1),
+		it.Equal(guid.SchemaVersion(guid.Tombstone()), guid.SchemaV1),
+		it.Equal(guid.SchemaVersion(guid.Nil()), guid.SchemaUnknown),
+		it.Equal(guid.SchemaVersion(guid.K{Hi: 1, Lo: 1}), guid.SchemaUnknown),
+	)
+}
+
+func TestUpgrade(t *testing.T) {
+	g := guid.G(guid.Clock)
+
+	x, err := guid.Upgrade(g, guid.SchemaV1)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(x, g),
+	)
+
+	_, err = guid.Upgrade(g, 2)
+	it.Then(t).ShouldNot(it.Nil(err))
+
+	_, err = guid.Upgrade(guid.Nil(), guid.SchemaV1)
+	it.Then(t).ShouldNot(it.Nil(err))
+}
@@ -0,0 +1,52 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+// Well-known sentinel identifiers are functions, so that callers cannot
+// reassign them.
+
+// Nil is all-zero identifier (e.g. "no parent"), encoded as empty string.
+func Nil() K { return K{} }
+
+// Tombstone is global identifier with all ones in ⟨𝒕⟩ and ⟨𝒅⟩, and with zero
+// ⟨𝒍⟩ and ⟨𝒔⟩ (e.g. "deleted"). It follows any identifier of the same node.
+func Tombstone() K { return tombstone }
+
+var tombstone = makeG(0, driftZ+7, 0xffffffffffffffff, 0)
+
+// IsZero reports whether k-ordered value is Nil. Encoders honor it to omit
+// optional identifiers, e.g. `json:",omitzero"`.
+func (uid K) IsZero() bool { return uid == Nil() }
+
+// PtrOf returns pointer to k-ordered value, Nil is mapped to nil pointer,
+// so that optional identifiers of DTOs are omitted by `json:",omitempty"`.
+func PtrOf(uid K) *K {
+	if uid == Nil() {
+		return nil
+	}
+	return &uid
+}
+
+// ValueOr returns k-ordered value of the pointer, def if pointer is nil
+func ValueOr(p *K, def K) K {
+	if p == nil {
+		return def
+	}
+	return *p
+}
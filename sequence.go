@@ -0,0 +1,71 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// registry of named sequences of the clock
+type sequences struct {
+	sync.Mutex
+	counters map[string]*int64
+}
+
+// Sequence derives logical clock that shares node location and ticker with
+// the origin clock but maintains independent ⟨𝒔⟩ sequence of the logical
+// stream, so that hot streams do not consume each other's per-tick budget.
+// Clocks of the same name derived from the same origin share the sequence.
+//
+// Identifiers of distinct streams are unique only within the stream, the
+// stream shall be part of identity (e.g. table or topic) of the identifier.
+func (c clock) Sequence(name string) LogicalClock {
+	step := c.step
+	if step == 0 {
+		step = 1
+	}
+
+	counter := c.sequences.of(name, step)
+	return c.with([]Config{
+		func(clock *clock) {
+			clock.unique = func() uint64 {
+				return uint64(atomic.AddInt64(counter, step) & 0x3fff)
+			}
+			clock.counter, clock.step = counter, step
+		},
+	})
+}
+
+// of returns named counter, descending counter starts from the top
+func (seq *sequences) of(name string, step int64) *int64 {
+	seq.Lock()
+	defer seq.Unlock()
+
+	counter, has := seq.counters[name]
+	if !has {
+		counter = new(int64)
+		if step < 0 {
+			*counter = math.MaxInt64
+		}
+		seq.counters[name] = counter
+	}
+	return counter
+}
@@ -0,0 +1,71 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestSequence(t *testing.T) {
+	clock := guid.NewClock(guid.WithNodeID(0xa))
+	orders := clock.Sequence("test.orders")
+	events := clock.Sequence("test.events")
+
+	a := guid.G(events)
+	for i := 0; i < 100; i++ {
+		guid.G(orders)
+	}
+	b := guid.G(events)
+	c := guid.G(clock.Sequence("test.events"))
+
+	it.Then(t).Should(
+		it.Equal(guid.Node(a), 0xa),
+		it.Equal(guid.Node(b), 0xa),
+		it.Equal(guid.Seq(b), guid.Seq(a)+1),
+		it.Equal(guid.Seq(c), guid.Seq(b)+1),
+	)
+}
+
+func TestSequencePerClock(t *testing.T) {
+	a := guid.NewClock(guid.WithNodeID(0xa))
+	b := guid.NewClock(guid.WithNodeID(0xb))
+
+	x := guid.G(a.Sequence("test.clock"))
+	guid.G(b.Sequence("test.clock"))
+	y := guid.G(a.Sequence("test.clock"))
+
+	it.Then(t).Should(
+		it.Equal(guid.Seq(y), guid.Seq(x)+1),
+		it.Equal(guid.Node(guid.G(b.Sequence("test.clock"))), 0xb),
+	)
+}
+
+func TestSequenceInverse(t *testing.T) {
+	clock := guid.NewClock(guid.WithClockInverse())
+	events := clock.Sequence("test.inverse")
+
+	a, b := guid.G(events), guid.G(events)
+	it.Then(t).Should(
+		it.Equal(guid.Seq(a), 0x3ffe),
+		it.Equal(guid.Seq(b), 0x3ffd),
+	)
+}
@@ -0,0 +1,191 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package session mints opaque session tokens from k-ordered values.
+//
+// The token is lexicographically sortable string of identifier followed by
+// id of signing key and HMAC-SHA256 over the identifier. Tokens are
+// stateless, time-ordered and tamper-evident. The expiry is derived from
+// the timestamp embedded into the identifier.
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// Errors reported by Verify and Retire
+var (
+	ErrMalformed  = errors.New("session: malformed token")
+	ErrUnknownKey = errors.New("session: unknown signing key")
+	ErrSignature  = errors.New("session: invalid signature")
+	ErrExpired    = errors.New("session: token is expired")
+	ErrActiveKey  = errors.New("session: active signing key cannot be retired")
+)
+
+const (
+	lenID  = 16
+	lenMAC = 16
+)
+
+// Key is a secret used to sign tokens
+type Key struct {
+	ID     byte
+	Secret []byte
+}
+
+// Sessions mints and verifies session tokens
+type Sessions struct {
+	mu    sync.RWMutex
+	clock guid.Chronos
+	ttl   time.Duration
+	keys  []Key
+}
+
+// New creates session tokens subsystem. The first key signs new tokens,
+// other keys are used to verify tokens signed before rotation.
+func New(clock guid.Chronos, ttl time.Duration, keys ...Key) *Sessions {
+	if len(keys) == 0 {
+		panic("session: signing key is not defined")
+	}
+
+	return &Sessions{clock: clock, ttl: ttl, keys: keys}
+}
+
+// Rotate makes the key active for signing, the previous keys remain valid
+// for verification.
+func (s *Sessions) Rotate(key Key) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = append([]Key{key}, s.keys...)
+}
+
+// Retire removes the key, tokens signed by it become invalid. The active
+// signing key cannot be retired, rotate it first.
+func (s *Sessions) Retire(id byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys[0].ID == id {
+		return ErrActiveKey
+	}
+
+	keys := make([]Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		if key.ID != id {
+			keys = append(keys, key)
+		}
+	}
+	s.keys = keys
+	return nil
+}
+
+// Mint creates new session token
+func (s *Sessions) Mint() string {
+	s.mu.RLock()
+	key := s.keys[0]
+	s.mu.RUnlock()
+
+	id := guid.String(guid.G(s.clock))
+
+	sig := make([]byte, 0, 1+lenMAC)
+	sig = append(sig, key.ID)
+	sig = append(sig, sign(key, id)...)
+
+	return id + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// Verify checks integrity and expiry of the token, returning identifier of session
+func (s *Sessions) Verify(token string) (guid.K, error) {
+	uid, keyID, mac, err := decode(token)
+	if err != nil {
+		return guid.K{}, err
+	}
+
+	key, has := s.key(keyID)
+	if !has {
+		return guid.K{}, ErrUnknownKey
+	}
+
+	if !hmac.Equal(mac, sign(key, token[:lenID])) {
+		return guid.K{}, ErrSignature
+	}
+
+	if !time.Now().Before(s.expiresAt(uid)) {
+		return guid.K{}, ErrExpired
+	}
+
+	return uid, nil
+}
+
+// ExpiresAt returns expiry time of the token, the token is not verified
+func (s *Sessions) ExpiresAt(token string) (time.Time, error) {
+	uid, _, _, err := decode(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return s.expiresAt(uid), nil
+}
+
+func (s *Sessions) expiresAt(uid guid.K) time.Time {
+	return guid.EpochT(uid).Add(s.ttl)
+}
+
+func (s *Sessions) key(id byte) (Key, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+func sign(key Key, id string) []byte {
+	h := hmac.New(sha256.New, key.Secret)
+	h.Write([]byte(id))
+	return h.Sum(nil)[:lenMAC]
+}
+
+func decode(token string) (guid.K, byte, []byte, error) {
+	if len(token) <= lenID {
+		return guid.K{}, 0, nil, ErrMalformed
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(token[lenID:])
+	if err != nil || len(sig) != 1+lenMAC {
+		return guid.K{}, 0, nil, ErrMalformed
+	}
+
+	uid, err := guid.FromStringG(token[:lenID])
+	if err != nil {
+		return guid.K{}, 0, nil, ErrMalformed
+	}
+
+	return uid, sig[0], sig[1:], nil
+}
@@ -0,0 +1,105 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package session_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/session"
+	"github.com/fogfish/it/v2"
+)
+
+func TestSessions(t *testing.T) {
+	s := session.New(guid.Clock, time.Hour, session.Key{ID: 1, Secret: []byte("secret-1")})
+
+	t.Run("Verify", func(t *testing.T) {
+		token := s.Mint()
+		uid, err := s.Verify(token)
+		exp, _ := s.ExpiresAt(token)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(guid.String(uid), token[:16]),
+			it.True(exp.Sub(time.Now()) > 59*time.Minute),
+		)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		token := []byte(s.Mint())
+		token[0] ^= 1
+
+		_, err := s.Verify(string(token))
+		it.Then(t).Should(
+			it.True(errors.Is(err, session.ErrSignature)),
+		)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := s.Verify("abc")
+		it.Then(t).Should(
+			it.True(errors.Is(err, session.ErrMalformed)),
+		)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		x := session.New(guid.Clock, -time.Second, session.Key{ID: 1, Secret: []byte("secret-1")})
+		_, err := x.Verify(s.Mint())
+		it.Then(t).Should(
+			it.True(errors.Is(err, session.ErrExpired)),
+		)
+	})
+
+	t.Run("Rotate", func(t *testing.T) {
+		old := s.Mint()
+		s.Rotate(session.Key{ID: 2, Secret: []byte("secret-2")})
+
+		_, errOld := s.Verify(old)
+		_, errNew := s.Verify(s.Mint())
+		it.Then(t).Should(
+			it.Nil(errOld),
+			it.Nil(errNew),
+		)
+
+		errRetire := s.Retire(1)
+		_, err := s.Verify(old)
+		it.Then(t).Should(
+			it.Nil(errRetire),
+			it.True(errors.Is(err, session.ErrUnknownKey)),
+		)
+	})
+
+	t.Run("RetireActive", func(t *testing.T) {
+		x := session.New(guid.Clock, time.Hour, session.Key{ID: 1, Secret: []byte("secret-1")})
+		err := x.Retire(1)
+		_, errMint := x.Verify(x.Mint())
+		it.Then(t).Should(
+			it.True(errors.Is(err, session.ErrActiveKey)),
+			it.Nil(errMint),
+		)
+
+		x.Rotate(session.Key{ID: 2, Secret: []byte("secret-2")})
+		err = x.Retire(2)
+		it.Then(t).Should(
+			it.True(errors.Is(err, session.ErrActiveKey)),
+		)
+	})
+}
@@ -0,0 +1,39 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "fmt"
+
+// Short projects k-ordered value to 64-bit integer, preserving the order.
+// The projection drops ⟨𝒍⟩ fraction same as ToL, which makes it suitable as
+// compact numeric handle (e.g. URL shorteners) while full value is kept
+// internally. Handles are unique only within single node.
+func Short(uid K) (uint64, error) {
+	if uid.Hi&^classMask > 0xffffffff {
+		return 0, fmt.Errorf("malformed k-order number: %v", uid)
+	}
+
+	return ToL(uid).Lo, nil
+}
+
+// ExpandShort is inverse to Short, it restores global k-ordered value using
+// ⟨𝒍⟩ fraction of the clock.
+func ExpandShort(clock Chronos, short uint64) K {
+	return FromL(clock, K{Lo: short})
+}
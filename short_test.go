@@ -0,0 +1,51 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestShort(t *testing.T) {
+	c := guid.NewClock(guid.WithNodeID(0xa))
+	a := guid.G(c)
+	b := guid.G(c)
+
+	sa, erra := guid.Short(a)
+	sb, errb := guid.Short(b)
+	sl, errl := guid.Short(guid.ToL(a))
+
+	it.Then(t).Should(
+		it.Nil(erra),
+		it.Nil(errb),
+		it.Nil(errl),
+		it.Less(sa, sb),
+		it.Equal(sa, sl),
+		it.Equal(guid.ExpandShort(c, sa), a),
+		it.Equal(guid.ExpandShort(c, sb), b),
+	)
+
+	_, err := guid.Short(guid.K{Hi: 1 << 40})
+	it.Then(t).ShouldNot(
+		it.Nil(err),
+	)
+}
@@ -0,0 +1,73 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrSignature is reported when signed identifier is not authenticated
+var ErrSignature = errors.New("guid: invalid signature")
+
+// length of truncated HMAC-SHA256
+const lenSignature = 12
+
+// Sign appends truncated HMAC to the string form of k-ordered value (see
+// FromString), so that identifiers received from untrusted clients are
+// authenticated without database lookup. Local values are signed in their
+// canonical local string (see StringLocal), the signature does not depend
+// on SetPromoteOnMarshal.
+func Sign(uid K, key []byte) string {
+	val := stableString(uid)
+	return val + "-" + base64.RawURLEncoding.EncodeToString(signature(key, val))
+}
+
+// VerifySigned authenticates signed identifier with any of keys, which
+// supports key rotation. The active key is given first.
+func VerifySigned(s string, keys ...[]byte) (K, error) {
+	// base64 encoding of signature, prefixed with '-'
+	at := len(s) - (lenSignature*4/3 + 1)
+	if at < 0 || s[at] != '-' {
+		return K{}, fmt.Errorf("malformed k-order number: %v", s)
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(s[at+1:])
+	if err != nil || len(mac) != lenSignature {
+		return K{}, fmt.Errorf("malformed k-order number: %v", s)
+	}
+
+	val := s[:at]
+	for _, key := range keys {
+		if hmac.Equal(mac, signature(key, val)) {
+			return FromString(val)
+		}
+	}
+
+	return K{}, ErrSignature
+}
+
+func signature(key []byte, val string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(val))
+	return h.Sum(nil)[:lenSignature]
+}
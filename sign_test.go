@@ -0,0 +1,70 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestSign(t *testing.T) {
+	k1, k2 := []byte("key-1"), []byte("key-2")
+	g := guid.G(guid.Clock)
+	l := guid.L(guid.Clock)
+
+	for _, uid := range []guid.K{g, l} {
+		s := guid.Sign(uid, k1)
+
+		a, erra := guid.VerifySigned(s, k1)
+		b, errb := guid.VerifySigned(s, k2, k1)
+		_, errc := guid.VerifySigned(s, k2)
+
+		it.Then(t).Should(
+			it.Nil(erra),
+			it.Equal(a, uid),
+			it.Nil(errb),
+			it.Equal(b, uid),
+			it.True(errors.Is(errc, guid.ErrSignature)),
+		)
+	}
+
+	_, err := guid.VerifySigned(g.String(), k1)
+	it.Then(t).ShouldNot(
+		it.Nil(err),
+	)
+}
+
+func TestSignPromote(t *testing.T) {
+	key := []byte("key-1")
+	l := guid.L(guid.NewClock(guid.WithNodeID(0xa)))
+	s := guid.Sign(l, key)
+
+	guid.SetPromoteOnMarshal(true)
+	defer guid.SetPromoteOnMarshal(false)
+
+	uid, err := guid.VerifySigned(guid.Sign(l, key), key)
+	it.Then(t).Should(
+		it.Equal(guid.Sign(l, key), s),
+		it.Nil(err),
+		it.Equal(uid, l),
+	)
+}
@@ -0,0 +1,145 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// NodeCount is estimated number of identifiers allocated by the node
+type NodeCount struct {
+	Node  uint64
+	Count uint64
+}
+
+// number of slots the sliding window is split into
+const sketchSlots = 8
+
+// NodeSketch reports the heaviest-producing node locations ⟨𝒍⟩ over sliding
+// window of ⟨𝒕⟩ time. The window is split into slots, each slot is space-saving
+// summary of bounded capacity. Counts are overestimated at most by count of
+// evicted nodes.
+type NodeSketch struct {
+	mu       sync.Mutex
+	capacity int
+	slot     uint64
+	latest   uint64
+	slots    map[uint64]map[uint64]uint64
+}
+
+// NewNodeSketch creates sketch tracking up to capacity nodes per slot of window
+func NewNodeSketch(capacity int, window time.Duration) *NodeSketch {
+	if capacity <= 0 || window < sketchSlots {
+		panic("guid: invalid node sketch configuration")
+	}
+
+	return &NodeSketch{
+		capacity: capacity,
+		slot:     uint64(window / sketchSlots),
+		slots:    map[uint64]map[uint64]uint64{},
+	}
+}
+
+// Add identifiers to the sketch
+func (s *NodeSketch) Add(ids ...K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, uid := range ids {
+		at := Time(uid) / s.slot
+		if at+sketchSlots <= s.latest {
+			continue
+		}
+
+		if at > s.latest {
+			s.latest = at
+			for key := range s.slots {
+				if key+sketchSlots <= at {
+					delete(s.slots, key)
+				}
+			}
+		}
+
+		counts, has := s.slots[at]
+		if !has {
+			counts = make(map[uint64]uint64, s.capacity)
+			s.slots[at] = counts
+		}
+		s.count(counts, Node(uid))
+	}
+}
+
+// space-saving update of counters
+func (s *NodeSketch) count(counts map[uint64]uint64, node uint64) {
+	if _, has := counts[node]; has || len(counts) < s.capacity {
+		counts[node]++
+		return
+	}
+
+	var (
+		minNode  uint64
+		minCount uint64
+		first    = true
+	)
+	for n, c := range counts {
+		if first || c < minCount {
+			minNode, minCount, first = n, c, false
+		}
+	}
+
+	delete(counts, minNode)
+	counts[node] = minCount + 1
+}
+
+// Top returns k heaviest-producing nodes within the window, the result is
+// empty if k <= 0
+func (s *NodeSketch) Top(k int) []NodeCount {
+	if k <= 0 {
+		return []NodeCount{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := map[uint64]uint64{}
+	for _, counts := range s.slots {
+		for node, c := range counts {
+			total[node] += c
+		}
+	}
+
+	seq := make([]NodeCount, 0, len(total))
+	for node, c := range total {
+		seq = append(seq, NodeCount{Node: node, Count: c})
+	}
+
+	sort.Slice(seq, func(i, j int) bool {
+		if seq[i].Count != seq[j].Count {
+			return seq[i].Count > seq[j].Count
+		}
+		return seq[i].Node < seq[j].Node
+	})
+
+	if len(seq) > k {
+		seq = seq[:k]
+	}
+	return seq
+}
@@ -0,0 +1,58 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestNodeSketch(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := guid.NewNodeSketch(16, time.Hour)
+
+	for i := 0; i < 1000; i++ {
+		node := uint32(i % 100)
+		if i%2 == 0 {
+			node = 0xab5e
+		}
+		uid, _ := guid.Compose(at.Add(time.Duration(i)*time.Second), node, 0)
+		s.Add(uid)
+	}
+
+	top := s.Top(1)
+	it.Then(t).Should(
+		it.Equal(len(top), 1),
+		it.Equal(top[0].Node, 0xab5e),
+		it.True(top[0].Count >= 500),
+		it.Equal(len(s.Top(0)), 0),
+		it.Equal(len(s.Top(-1)), 0),
+	)
+
+	// window slides forward, old counts are expired
+	late, _ := guid.Compose(at.Add(3*time.Hour), 0x1, 0)
+	s.Add(late)
+
+	it.Then(t).Should(
+		it.Seq(s.Top(10)).Equal(guid.NodeCount{Node: 0x1, Count: 1}),
+	)
+}
@@ -0,0 +1,90 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "time"
+
+// LeapSmear is a model of leap second smearing applied by time services
+// (e.g. cloud NTP). Offset returns difference between POSIX time and smeared
+// time observed by the host at the smeared instant t.
+type LeapSmear interface {
+	Offset(t time.Time) time.Duration
+}
+
+// LinearSmear is a model of positive leap second, which is smeared linearly
+// over the window centered at the leap instant (e.g. 24 hours noon to noon).
+func LinearSmear(leap time.Time, window time.Duration) LeapSmear {
+	return linearSmear{
+		leap:  leap,
+		start: leap.Add(-window / 2),
+		end:   leap.Add(window / 2),
+	}
+}
+
+type linearSmear struct{ leap, start, end time.Time }
+
+func (smear linearSmear) Offset(t time.Time) time.Duration {
+	if !t.After(smear.start) || !t.Before(smear.end) {
+		return 0
+	}
+
+	// smeared clock lags behind linearly, POSIX repeats the leap second
+	lag := time.Duration(float64(time.Second) * float64(t.Sub(smear.start)) / float64(smear.end.Sub(smear.start)))
+	if t.Add(lag).Before(smear.leap) {
+		return lag
+	}
+	return lag - time.Second
+}
+
+// WithLeapSmear configures clock on the host synchronized with leap smearing
+// time service. The clock corrects ⟨𝒕⟩ timestamps to POSIX time, so that
+// identifiers from hosts of different providers are comparable. The option
+// shall follow options that define the timestamp generator.
+func WithLeapSmear(smear LeapSmear) Config {
+	return func(clock *clock) {
+		ticker := clock.ticker
+		clock.ticker = func() uint64 {
+			return unsmear(smear, ticker())
+		}
+
+		if source := clock.source; source != nil {
+			clock.source = TimeSourceFunc(func() (uint64, time.Duration) {
+				t, ε := source.Now()
+				return unsmear(smear, t), ε
+			})
+		}
+	}
+}
+
+func unsmear(smear LeapSmear, t uint64) uint64 {
+	return uint64(int64(t) + int64(smear.Offset(time.Unix(0, int64(t)))))
+}
+
+// EpochOption adjusts timestamp decoded from identifier
+type EpochOption func(time.Time) time.Time
+
+// WithSmearedEpoch converts decoded POSIX timestamp into the time frame of
+// the leap smearing time service. Timestamps within the repeated leap second
+// of POSIX time are ambiguous, they are mapped to either occurrence.
+func WithSmearedEpoch(smear LeapSmear) EpochOption {
+	return func(t time.Time) time.Time {
+		s := t.Add(-smear.Offset(t))
+		return t.Add(-smear.Offset(s))
+	}
+}
@@ -0,0 +1,59 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestLeapSmear(t *testing.T) {
+	leap := time.Date(2016, 12, 31, 23, 59, 60, 0, time.UTC)
+	smear := guid.LinearSmear(leap, 24*time.Hour)
+
+	t.Run("Offset", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Equal(smear.Offset(leap.Add(-13*time.Hour)), 0),
+			it.Equal(smear.Offset(leap.Add(13*time.Hour)), 0),
+			it.Equal(smear.Offset(leap.Add(-6*time.Hour)), 250*time.Millisecond),
+			it.Equal(smear.Offset(leap.Add(6*time.Hour)), -250*time.Millisecond),
+		)
+	})
+
+	t.Run("Clock", func(t *testing.T) {
+		at := leap.Add(-6 * time.Hour)
+		c := guid.NewClock(
+			guid.WithClock(func() uint64 { return uint64(at.UnixNano()) }),
+			guid.WithLeapSmear(smear),
+		)
+		uid := guid.G(c)
+
+		posix := guid.EpochT(uid)
+		smeared := guid.EpochT(uid, guid.WithSmearedEpoch(smear))
+
+		it.Then(t).Should(
+			it.True(posix.Sub(at) >= 250*time.Millisecond-time.Millisecond),
+			it.True(posix.Sub(at) <= 250*time.Millisecond),
+			it.True(smeared.Sub(at).Abs() < time.Millisecond),
+		)
+	})
+}
@@ -0,0 +1,122 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package sync implements anti-entropy protocol of k-ordered event stores.
+// Nodes exchange per-node high watermarks (guid.Checkpoint) and compute
+// ranges of identifiers missing at the local replica.
+package sync
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// Range of identifiers allocated by the node within (After, Until]
+type Range struct {
+	Node  uint64
+	After guid.K
+	Until guid.K
+}
+
+// Missing computes ranges of identifiers known by remote replica but missing
+// at local one, ranges are ordered by node location.
+func Missing(local, remote guid.Checkpoint) []Range {
+	seq := make([]Range, 0)
+	for node, until := range remote {
+		after, has := local[node]
+		if !has || guid.After(until, after) {
+			seq = append(seq, Range{Node: node, After: after, Until: until})
+		}
+	}
+
+	sort.Slice(seq, func(i, j int) bool { return seq[i].Node < seq[j].Node })
+	return seq
+}
+
+// maximum size of checkpoint message and number of its watermarks, 1M nodes
+const (
+	maxMessage = 1 << 24
+	maxNodes   = 1 << 20
+)
+
+// Exchange sends local watermarks to the peer and receives peer's watermarks,
+// returning ranges missing at local replica. Both peers call Exchange
+// concurrently. Message is length-prefixed binary checkpoint.
+func Exchange(rw io.ReadWriter, local guid.Checkpoint) ([]Range, error) {
+	msg, err := local.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		_, err := rw.Write(binary.BigEndian.AppendUint32(nil, uint32(len(msg))))
+		if err == nil {
+			_, err = rw.Write(msg)
+		}
+		sent <- err
+	}()
+
+	remote, err := receive(rw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := <-sent; err != nil {
+		return nil, err
+	}
+
+	return Missing(local, remote), nil
+}
+
+func receive(r io.Reader) (guid.Checkpoint, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxMessage {
+		return nil, fmt.Errorf("sync: message of %d bytes is too large", n)
+	}
+
+	// the buffer grows with received bytes, the peer cannot reserve memory
+	// by announcing the size only
+	msg, err := io.ReadAll(io.LimitReader(r, int64(n)))
+	if err != nil {
+		return nil, err
+	}
+	if len(msg) != int(n) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if count, size := binary.Uvarint(msg); size <= 0 || count > maxNodes {
+		return nil, fmt.Errorf("sync: malformed checkpoint message")
+	}
+
+	var remote guid.Checkpoint
+	if err := remote.UnmarshalBinary(msg); err != nil {
+		return nil, err
+	}
+
+	return remote, nil
+}
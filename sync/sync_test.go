@@ -0,0 +1,116 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package sync_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/sync"
+	"github.com/fogfish/it/v2"
+)
+
+func TestMissing(t *testing.T) {
+	a := guid.NewClock(guid.WithNodeID(0xa))
+	b := guid.NewClock(guid.WithNodeID(0xb))
+
+	a1, a2, b1 := guid.G(a), guid.G(a), guid.G(b)
+
+	local := guid.Checkpoint{}
+	local.Add(a1)
+
+	remote := guid.Checkpoint{}
+	remote.Add(a2, b1)
+
+	it.Then(t).Should(
+		it.Seq(sync.Missing(local, remote)).Equal(
+			sync.Range{Node: 0xa, After: a1, Until: a2},
+			sync.Range{Node: 0xb, Until: b1},
+		),
+		it.Equal(len(sync.Missing(remote, local)), 0),
+	)
+}
+
+func TestExchange(t *testing.T) {
+	a := guid.NewClock(guid.WithNodeID(0xa))
+	b := guid.NewClock(guid.WithNodeID(0xb))
+
+	x := guid.Checkpoint{}
+	x.Add(guid.G(a))
+
+	y := guid.Checkpoint{}
+	y.Add(guid.G(b))
+
+	cx, cy := net.Pipe()
+	defer cx.Close()
+	defer cy.Close()
+
+	type result struct {
+		seq []sync.Range
+		err error
+	}
+	ch := make(chan result)
+	go func() {
+		seq, err := sync.Exchange(cy, y)
+		ch <- result{seq, err}
+	}()
+
+	sx, errx := sync.Exchange(cx, x)
+	ry := <-ch
+
+	it.Then(t).Should(
+		it.Nil(errx),
+		it.Nil(ry.err),
+		it.Seq(sx).Equal(sync.Range{Node: 0xb, Until: y[0xb]}),
+		it.Seq(ry.seq).Equal(sync.Range{Node: 0xa, Until: x[0xa]}),
+	)
+}
+
+func TestExchangeHostile(t *testing.T) {
+	message := func(size uint32, payload ...byte) []byte {
+		return append(binary.BigEndian.AppendUint32(nil, size), payload...)
+	}
+
+	for _, msg := range [][]byte{
+		// huge count of watermarks
+		message(5, 0xff, 0xff, 0xff, 0xff, 0x0f),
+		message(10, binary.AppendUvarint(nil, 1<<62)...),
+		message(4, 0xff, 0xff, 0xff, 0x7f),
+		// announced size is not delivered
+		message(1<<24, 0x01),
+		// announced size is too large
+		message(1 << 30),
+		// malformed count
+		message(2, 0xff, 0xff),
+	} {
+		peer := struct {
+			io.Reader
+			io.Writer
+		}{bytes.NewReader(msg), io.Discard}
+
+		_, err := sync.Exchange(peer, guid.Checkpoint{})
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"fmt"
+	"time"
+)
+
+// TemplateFuncs returns functions for text/template and html/template, so
+// that templates decode identifiers inline. Functions accept K, *K or its
+// string form (see FromString):
+//
+//	guidTime   ⟨𝒕⟩ fraction as time.Time
+//	guidNode   ⟨𝒍⟩ fraction
+//	guidSeq    ⟨𝒔⟩ fraction
+//	guidShort  64-bit form of identifier (see Short)
+//	guidString lexicographically sortable string (see String)
+//	guidBase62 base62 string (see Base62)
+//	guidLocal  local form of identifier (see ToL)
+//
+// Usage:
+//
+//	template.New("report").Funcs(guid.TemplateFuncs())
+func TemplateFuncs() map[string]any {
+	return map[string]any{
+		"guidTime": func(v any) (time.Time, error) {
+			uid, err := templateK(v)
+			return EpochT(uid), err
+		},
+		"guidNode": func(v any) (uint64, error) {
+			uid, err := templateK(v)
+			return Node(uid), err
+		},
+		"guidSeq": func(v any) (uint64, error) {
+			uid, err := templateK(v)
+			return Seq(uid), err
+		},
+		"guidShort": func(v any) (uint64, error) {
+			uid, err := templateK(v)
+			if err != nil {
+				return 0, err
+			}
+			return Short(uid)
+		},
+		"guidString": func(v any) (string, error) {
+			uid, err := templateK(v)
+			return String(uid), err
+		},
+		"guidBase62": func(v any) (string, error) {
+			uid, err := templateK(v)
+			return Base62(uid), err
+		},
+		"guidLocal": func(v any) (K, error) {
+			uid, err := templateK(v)
+			return ToL(uid), err
+		},
+	}
+}
+
+func templateK(v any) (K, error) {
+	switch uid := v.(type) {
+	case K:
+		return uid, nil
+	case *K:
+		if uid == nil {
+			return K{}, nil
+		}
+		return *uid, nil
+	case string:
+		return FromString(uid)
+	default:
+		return K{}, fmt.Errorf("malformed k-order number: %T", v)
+	}
+}
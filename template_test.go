@@ -0,0 +1,73 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	uid, _ := guid.Compose(at, 0xa, 5)
+	short, _ := guid.Short(uid)
+
+	t.Run("Text", func(t *testing.T) {
+		tpl := template.Must(template.New("t").Funcs(guid.TemplateFuncs()).Parse(
+			`{{guidNode .ID}} {{guidSeq .ID}} {{(guidTime .Str).UTC.Year}} {{guidShort .ID}} {{guidString (guidLocal .ID)}}`,
+		))
+
+		var sb strings.Builder
+		err := tpl.Execute(&sb, map[string]any{"ID": uid, "Str": guid.String(uid)})
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(sb.String(), "10 5 2024 "+strconv.FormatUint(short, 10)+" "+guid.String(guid.ToL(uid))),
+		)
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		tpl := htmltemplate.Must(htmltemplate.New("t").Funcs(guid.TemplateFuncs()).Parse(
+			`{{guidBase62 .}}`,
+		))
+
+		var sb strings.Builder
+		err := tpl.Execute(&sb, &uid)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(sb.String(), guid.Base62(uid)),
+		)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		tpl := template.Must(template.New("t").Funcs(guid.TemplateFuncs()).Parse(`{{guidNode .}}`))
+
+		var sb strings.Builder
+		it.Then(t).ShouldNot(
+			it.Nil(tpl.Execute(&sb, 42)),
+			it.Nil(tpl.Execute(&sb, "!!!")),
+		)
+	})
+}
@@ -0,0 +1,108 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// tenant bits of the latest family of clocks, used by TenantOf
+var tenantBits atomic.Uint32
+
+// Tenants is a family of logical clocks, one clock per tenant. The node
+// location ⟨𝒍⟩ of each clock embeds the tenant index into its high bits,
+// the remaining low bits are defined by the base clock configuration.
+// It allows to route and shard identifiers by tenant directly.
+//
+//	   tenantBits    32 - tenantBits
+//	|-------------|------------------|
+//	    tenant          location
+type Tenants struct {
+	mu     sync.Mutex
+	proto  LogicalClock
+	bits   uint
+	clocks map[uint32]Chronos
+}
+
+// NewTenantClocks creates family of clocks using base configuration,
+// reserving tenantBits high bits of node location for tenant index.
+// The layout of tenant index becomes package-level one (see TenantOf).
+func NewTenantClocks(base Config, bits uint) *Tenants {
+	if bits == 0 || bits > 32 {
+		panic("guid: tenant bits must be within 1 and 32")
+	}
+
+	var proto LogicalClock
+	if base == nil {
+		proto = NewClock()
+	} else {
+		proto = NewClock(base)
+	}
+
+	tenantBits.Store(uint32(bits))
+
+	return &Tenants{
+		proto:  proto,
+		bits:   bits,
+		clocks: map[uint32]Chronos{},
+	}
+}
+
+// Clock returns the logical clock of the tenant
+func (tenants *Tenants) Clock(tenant uint32) Chronos {
+	if uint64(tenant)>>tenants.bits != 0 {
+		panic("guid: tenant index exceeds tenant bits")
+	}
+
+	tenants.mu.Lock()
+	defer tenants.mu.Unlock()
+
+	if c, has := tenants.clocks[tenant]; has {
+		return c
+	}
+
+	shift := 32 - tenants.bits
+	c := tenants.proto.Clone(func(c *clock) {
+		c.location = uint64(tenant)<<shift | c.location&(1<<shift-1)
+		// the location is derived from tenant index, it is not re-drawn
+		c.random = false
+	})
+
+	tenants.clocks[tenant] = c
+	return c
+}
+
+// TenantOf returns the tenant index embedded into k-ordered value using
+// the layout of the latest family of clocks (see NewTenantClocks). Local
+// (64-bit) values do not carry node location, tenant 0 is returned.
+//
+// The function assumes single family of clocks per process, identifiers do
+// not carry the layout. Applications with several families decode tenants
+// through the family (see Tenants.TenantOf), otherwise the index is wrong.
+func TenantOf(uid K) uint32 {
+	return uint32(Node(uid) >> (32 - tenantBits.Load()))
+}
+
+// TenantOf returns the tenant index embedded into k-ordered value using
+// the layout of the family. Local (64-bit) values do not carry node
+// location, tenant 0 is returned.
+func (tenants *Tenants) TenantOf(uid K) uint32 {
+	return uint32(Node(uid) >> (32 - tenants.bits))
+}
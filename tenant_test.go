@@ -0,0 +1,72 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestTenants(t *testing.T) {
+	tenants := guid.NewTenantClocks(guid.WithNodeID(0xffffffff), 8)
+
+	a := guid.G(tenants.Clock(0x12))
+	b := guid.G(tenants.Clock(0x34))
+
+	it.Then(t).Should(
+		it.Equal(tenants.TenantOf(a), 0x12),
+		it.Equal(tenants.TenantOf(b), 0x34),
+		it.Equal(guid.TenantOf(a), 0x12),
+		it.Equal(guid.TenantOf(b), 0x34),
+		it.Equal(guid.TenantOf(guid.L(tenants.Clock(0x12))), 0),
+		it.Equal(guid.Node(a), 0x12ffffff),
+		it.Equal(guid.Node(b), 0x34ffffff),
+		it.Equal(tenants.Clock(0x12), tenants.Clock(0x12)),
+	)
+
+	t.Run("Random", func(t *testing.T) {
+		tenants := guid.NewTenantClocks(nil, 4)
+		a := guid.G(tenants.Clock(0x1))
+		b := guid.G(tenants.Clock(0x2))
+
+		it.Then(t).Should(
+			it.Equal(tenants.TenantOf(a), 0x1),
+			it.Equal(tenants.TenantOf(b), 0x2),
+			it.Equal(guid.TenantOf(a), 0x1),
+			it.Equal(guid.TenantOf(b), 0x2),
+			it.Equal(guid.Node(a)&0x0fffffff, guid.Node(b)&0x0fffffff),
+		)
+	})
+}
+
+func TestTenantsDerived(t *testing.T) {
+	tenants := guid.NewTenantClocks(guid.WithNodeID(0xffffffff), 8)
+
+	c, ok := tenants.Clock(0x12).(guid.LogicalClock)
+	it.Then(t).Should(it.True(ok))
+
+	a := guid.G(c.Sequence("test.tenant"))
+	b := guid.G(c.Sequence("test.tenant"))
+	it.Then(t).Should(
+		it.Equal(guid.Node(a), 0x12ffffff),
+		it.Equal(guid.Seq(b), guid.Seq(a)+1),
+	)
+}
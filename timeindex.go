@@ -0,0 +1,278 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TimeIndex maps coarse time buckets of ⟨𝒕⟩ timestamp to sets of record
+// offsets (e.g. index of record in klog or position in user's storage). It
+// accelerates lookup of records within time interval, the lookup returns
+// offsets of all buckets overlapping the interval, records are filtered by
+// exact timestamp afterwards. Sets of offsets are roaring-style bitsets.
+type TimeIndex struct {
+	mu      sync.RWMutex
+	bucket  uint64
+	buckets map[uint64]*bitset
+}
+
+// NewTimeIndex creates index with given time bucket
+func NewTimeIndex(bucket time.Duration) *TimeIndex {
+	if bucket <= 0 {
+		bucket = time.Minute
+	}
+
+	return &TimeIndex{
+		bucket:  uint64(bucket),
+		buckets: make(map[uint64]*bitset),
+	}
+}
+
+// Add indexes record offset by ⟨𝒕⟩ timestamp of k-ordered value
+func (idx *TimeIndex) Add(offset uint32, uid K) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.add(offset, uid)
+}
+
+// AddAll indexes stream of records, e.g. klog.Reader.All()
+func (idx *TimeIndex) AddAll(seq iter.Seq2[int, K]) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for offset, uid := range seq {
+		idx.add(uint32(offset), uid)
+	}
+}
+
+func (idx *TimeIndex) add(offset uint32, uid K) {
+	at := Time(uid) / idx.bucket
+	set, has := idx.buckets[at]
+	if !has {
+		set = &bitset{}
+		idx.buckets[at] = set
+	}
+	set.add(offset)
+}
+
+// Lookup returns ascending offsets of records within time buckets overlapping
+// the interval [from, to).
+func (idx *TimeIndex) Lookup(from, to time.Time) []uint32 {
+	if !from.Before(to) {
+		return nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// ⟨𝒕⟩ timestamp is truncated to tick, records within interval
+	// are at truncated timestamps [tick(from), tick(to - 1ns)]
+	lo := uint64(from.UnixNano()) >> bitsSeqDrift << bitsSeqDrift / idx.bucket
+	hi := uint64(to.UnixNano()-1) >> bitsSeqDrift << bitsSeqDrift / idx.bucket
+
+	var seq []uint32
+	for at, set := range idx.buckets {
+		if lo <= at && at <= hi {
+			seq = set.appendTo(seq)
+		}
+	}
+
+	slices.Sort(seq)
+	return seq
+}
+
+// MarshalBinary encodes index as sequence of time buckets ordered by time,
+// each bucket is followed by delta-encoded offsets.
+func (idx *TimeIndex) MarshalBinary() ([]byte, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	keys := make([]uint64, 0, len(idx.buckets))
+	for at := range idx.buckets {
+		keys = append(keys, at)
+	}
+	slices.Sort(keys)
+
+	b := binary.AppendUvarint(nil, idx.bucket)
+	b = binary.AppendUvarint(b, uint64(len(keys)))
+	for _, at := range keys {
+		seq := idx.buckets[at].appendTo(nil)
+		b = binary.AppendUvarint(b, at)
+		b = binary.AppendUvarint(b, uint64(len(seq)))
+
+		prev := uint32(0)
+		for _, x := range seq {
+			b = binary.AppendUvarint(b, uint64(x-prev))
+			prev = x
+		}
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary decodes index
+func (idx *TimeIndex) UnmarshalBinary(b []byte) error {
+	next := func() (uint64, error) {
+		x, size := binary.Uvarint(b)
+		if size <= 0 {
+			return 0, fmt.Errorf("malformed time index: truncated")
+		}
+		b = b[size:]
+		return x, nil
+	}
+
+	bucket, err := next()
+	if err != nil {
+		return err
+	}
+	if bucket == 0 {
+		return fmt.Errorf("malformed time index: zero bucket")
+	}
+
+	n, err := next()
+	if err != nil {
+		return err
+	}
+
+	buckets := make(map[uint64]*bitset)
+	for i := uint64(0); i < n; i++ {
+		at, err := next()
+		if err != nil {
+			return err
+		}
+
+		size, err := next()
+		if err != nil {
+			return err
+		}
+
+		set := &bitset{}
+		prev := uint64(0)
+		for j := uint64(0); j < size; j++ {
+			delta, err := next()
+			if err != nil {
+				return err
+			}
+			prev += delta
+			set.add(uint32(prev))
+		}
+		buckets[at] = set
+	}
+
+	if len(b) != 0 {
+		return fmt.Errorf("malformed time index: %d trailing bytes", len(b))
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.bucket, idx.buckets = bucket, buckets
+	return nil
+}
+
+// bitset is roaring-style set of 32-bit integers. Integers are chunked by
+// high 16 bits, each chunk is sorted array of low 16 bits while it is sparse,
+// it is converted to bitmap of 2¹⁶ bits once it is dense.
+type bitset struct {
+	keys   []uint16
+	chunks []*chunk
+}
+
+// chunk is array of at most arrayMax values or bitmap
+type chunk struct {
+	array  []uint16
+	bitmap []uint64
+}
+
+const arrayMax = 4096
+
+func (set *bitset) add(x uint32) {
+	key := uint16(x >> 16)
+
+	i := len(set.keys)
+	if i == 0 || set.keys[i-1] != key {
+		i = sort.Search(len(set.keys), func(i int) bool { return set.keys[i] >= key })
+		if i == len(set.keys) || set.keys[i] != key {
+			set.keys = slices.Insert(set.keys, i, key)
+			set.chunks = slices.Insert(set.chunks, i, &chunk{})
+		}
+	} else {
+		i--
+	}
+
+	set.chunks[i].add(uint16(x))
+}
+
+func (c *chunk) add(x uint16) {
+	if c.bitmap != nil {
+		c.bitmap[x>>6] |= 1 << (x & 63)
+		return
+	}
+
+	n := len(c.array)
+	switch {
+	case n == 0 || c.array[n-1] < x:
+		c.array = append(c.array, x)
+	default:
+		i, has := slices.BinarySearch(c.array, x)
+		if has {
+			return
+		}
+		c.array = slices.Insert(c.array, i, x)
+	}
+
+	if len(c.array) > arrayMax {
+		c.bitmap = make([]uint64, 1<<16/64)
+		for _, x := range c.array {
+			c.bitmap[x>>6] |= 1 << (x & 63)
+		}
+		c.array = nil
+	}
+}
+
+// appendTo appends ascending values of the set
+func (set *bitset) appendTo(dst []uint32) []uint32 {
+	for i, c := range set.chunks {
+		hi := uint32(set.keys[i]) << 16
+		if c.bitmap == nil {
+			for _, x := range c.array {
+				dst = append(dst, hi|uint32(x))
+			}
+			continue
+		}
+
+		for w, word := range c.bitmap {
+			for word != 0 {
+				x := bits.TrailingZeros64(word)
+				dst = append(dst, hi|uint32(w<<6|x))
+				word &= word - 1
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,117 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestTimeIndex(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	idx := guid.NewTimeIndex(time.Minute)
+
+	// 10K records per minute, dense chunks of bitset
+	at := func(i int) time.Time { return base.Add(time.Duration(i) * 6 * time.Millisecond) }
+	for i := 0; i < 30000; i++ {
+		idx.Add(uint32(i), guid.FromT(at(i)))
+	}
+
+	for _, interval := range [][2]time.Time{
+		{base, base.Add(time.Minute)},
+		{base.Add(time.Minute), base.Add(2 * time.Minute)},
+		{base.Add(30 * time.Second), base.Add(90 * time.Second)},
+	} {
+		seq := idx.Lookup(interval[0], interval[1])
+		has := make(map[uint32]bool, len(seq))
+		for _, x := range seq {
+			has[x] = true
+		}
+
+		for i := 0; i < 30000; i++ {
+			if !at(i).Before(interval[0]) && at(i).Before(interval[1]) {
+				it.Then(t).Should(it.True(has[uint32(i)]))
+			}
+		}
+
+		it.Then(t).Should(
+			it.Less(len(seq), 20001),
+		)
+	}
+
+	seq := idx.Lookup(base.Add(10*time.Second), base.Add(20*time.Second))
+	it.Then(t).Should(
+		it.Equal(len(seq), 10000),
+		it.Equal(seq[0], 1),
+		it.Equal(seq[len(seq)-1], 10000),
+	)
+
+	it.Then(t).Should(
+		it.Equal(len(idx.Lookup(base.Add(time.Hour), base.Add(2*time.Hour))), 0),
+		it.Equal(len(idx.Lookup(base.Add(time.Minute), base)), 0),
+	)
+}
+
+func TestTimeIndexAddAll(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := []guid.K{
+		guid.FromT(base.Add(time.Second)),
+		guid.FromT(base.Add(time.Hour)),
+		guid.FromT(base.Add(2 * time.Second)),
+	}
+
+	idx := guid.NewTimeIndex(time.Minute)
+	idx.AddAll(func(yield func(int, guid.K) bool) {
+		for i, uid := range ids {
+			if !yield(i, uid) {
+				return
+			}
+		}
+	})
+
+	it.Then(t).Should(
+		it.Seq(idx.Lookup(base.Add(time.Second), base.Add(time.Minute))).Equal(0, 2),
+	)
+}
+
+func TestTimeIndexCodec(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	idx := guid.NewTimeIndex(time.Minute)
+	for i := 0; i < 10000; i++ {
+		idx.Add(uint32(i*7), guid.FromT(base.Add(time.Duration(i)*time.Second)))
+	}
+
+	b, err := idx.MarshalBinary()
+	it.Then(t).Should(it.Nil(err))
+
+	var x guid.TimeIndex
+	err = x.UnmarshalBinary(b)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Seq(x.Lookup(base, base.Add(time.Hour))).Equal(idx.Lookup(base, base.Add(time.Hour))...),
+	)
+
+	err = x.UnmarshalBinary(b[:len(b)-1])
+	it.Then(t).ShouldNot(
+		it.Nil(err),
+	)
+}
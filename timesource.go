@@ -0,0 +1,110 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// TimeSource is an external clock with bounded uncertainty (e.g. PTP or
+// TrueTime-like services). The true time is within interval [t - ε, t + ε].
+type TimeSource interface {
+	// Now returns unix timestamp in nanoseconds and its uncertainty ε
+	Now() (t uint64, ε time.Duration)
+}
+
+// TimeSourceFunc adapts ordinary function to TimeSource
+type TimeSourceFunc func() (uint64, time.Duration)
+
+func (f TimeSourceFunc) Now() (uint64, time.Duration) { return f() }
+
+// WithTimeSource configures ⟨𝒕⟩ timestamp generator from external time source.
+// Unless drift is explicitly given, identifiers use the smallest drift that
+// covers the uncertainty interval 2ε of observations. The drift of the clock
+// only grows, identifiers remain k-ordered while uncertainty fluctuates.
+func WithTimeSource(source TimeSource) Config {
+	return func(clock *clock) {
+		clock.source = source
+		clock.drift = new(atomic.Uint64)
+		clock.ticker = func() uint64 {
+			t, _ := source.Now()
+			return t
+		}
+		clock.unique = uniqueInt
+		clock.counter, clock.step = &unique, 1
+	}
+}
+
+// observe all fractions of the clock together with drift bits
+func observeWithDrift(c Chronos, drift []time.Duration) (t, l, s, d uint64) {
+	t, l, s, d, _ = observeIf(c, drift, nil)
+	return
+}
+
+// observe all fractions of the clock together with drift bits, the
+// observation is issued if admitted. Issued observations are journaled.
+func observeIf(c Chronos, drift []time.Duration, admit func(t, s uint64) bool) (t, l, s, d uint64, ok bool) {
+	clock, isClock := c.(*clock)
+	switch {
+	case isClock && clock.journal != nil:
+		clock.journal.Lock()
+		defer clock.journal.Unlock()
+		t, d = clock.tick(drift)
+		l, s = clock.location, clock.unique()
+	case isClock && clock.source != nil:
+		t, d = clock.tick(drift)
+		l, s = clock.location, clock.unique()
+	default:
+		t, l, s = observe(c)
+		d = driftInBits(drift)
+	}
+
+	if admit != nil && !admit(t, s) {
+		return t, l, s, d, false
+	}
+
+	if isClock && clock.journal != nil {
+		clock.journal.write(t, l, s)
+	}
+	return t, l, s, d, true
+}
+
+// observe ⟨𝒕⟩ fraction of the clock together with drift bits
+func (clock *clock) tick(drift []time.Duration) (t, d uint64) {
+	if clock.source != nil && len(drift) == 0 {
+		t, ε := clock.source.Now()
+		return t, clock.widen(driftInBits([]time.Duration{2 * ε}))
+	}
+
+	return clock.ticker(), driftInBits(drift)
+}
+
+// widen drift bits of the clock to cover the given drift
+func (clock *clock) widen(d uint64) uint64 {
+	for {
+		x := clock.drift.Load()
+		if d <= x {
+			return x
+		}
+		if clock.drift.CompareAndSwap(x, d) {
+			return d
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestWithTimeSource(t *testing.T) {
+	at := uint64(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC).UnixNano())
+	ε := 10 * time.Second
+
+	c := guid.NewClock(
+		guid.WithNodeID(0xa),
+		guid.WithTimeSource(guid.TimeSourceFunc(
+			func() (uint64, time.Duration) { return at, ε },
+		)),
+	)
+
+	g := guid.G(c)
+	l := guid.L(c)
+
+	it.Then(t).Should(
+		it.Equal(guid.Time(g), at>>17<<17),
+		it.Equal(guid.Node(g), 0xa),
+		// 2ε = 20s fits the smallest drift step
+		it.Equal(g.Hi>>29, 1),
+		it.Equal(l.Lo>>61, 1),
+		// explicit drift takes precedence
+		it.Equal(guid.G(c, 5*time.Minute).Hi>>29, 4),
+	)
+}
+
+func TestWithTimeSourceFluctuating(t *testing.T) {
+	at := uint64(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC).UnixNano())
+	seq := []time.Duration{10 * time.Second, 2 * time.Minute, 10 * time.Second, time.Second}
+
+	i := 0
+	c := guid.NewClock(
+		guid.WithNodeID(0xa),
+		guid.WithTimeSource(guid.TimeSourceFunc(
+			func() (uint64, time.Duration) {
+				at += uint64(time.Millisecond)
+				ε := seq[i%len(seq)]
+				i++
+				return at, ε
+			},
+		)),
+	)
+
+	prev := guid.G(c)
+	for range 8 {
+		uid := guid.G(c)
+		it.Then(t).Should(
+			it.True(guid.Before(prev, uid)),
+		)
+		prev = uid
+	}
+
+	it.Then(t).Should(
+		// 2ε = 4m fits 274s drift step
+		it.Equal(prev.Hi>>29, 3),
+	)
+}
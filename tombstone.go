@@ -0,0 +1,49 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+// tombstone marker occupies the bit of Hi, which is not used by identifiers
+const tombstoneBit = uint64(1) << 61
+
+// TombstoneOf mints delete-marker of k-ordered value. The marker keeps ⟨𝒕⟩,
+// ⟨𝒍⟩ and ⟨𝒔⟩ fractions of the original value, so that markers never collide
+// with values allocated by any node. Local values are promoted to global ones
+// with zero ⟨𝒍⟩ fraction.
+//
+// Binary and string forms of the marker are the forms of the original value
+// suffixed with the lowest symbol (zero byte and '.'), so that the marker
+// survives serialization and sorts immediately after the original value in
+// byte order, same as in k-order (see Before). Fixed-width forms (e.g.
+// frames, base62 and UUID) do not carry the marker.
+func TombstoneOf(of K) K {
+	if of.Hi == 0 {
+		of = makeG(0, driftOf(of), Time(of), Seq(of))
+	}
+	return K{Hi: of.Hi | tombstoneBit, Lo: of.Lo}
+}
+
+// IsTombstone reports whether k-ordered value is delete-marker (see TombstoneOf)
+func IsTombstone(uid K) bool {
+	return uid.Hi&tombstoneBit != 0
+}
+
+// IsTombstoneOf reports whether a is delete-marker of b (see TombstoneOf)
+func IsTombstoneOf(a, b K) bool {
+	return b != Nil() && !IsTombstone(b) && a == TombstoneOf(b)
+}
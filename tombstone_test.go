@@ -0,0 +1,122 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestTombstoneOf(t *testing.T) {
+	clock := guid.NewClock(
+		guid.WithNodeID(0xa),
+		guid.WithUnique(func() uint64 { return 7 }),
+	)
+
+	for _, uid := range []guid.K{guid.G(clock), guid.L(clock)} {
+		ts := guid.TombstoneOf(uid)
+
+		it.Then(t).Should(
+			it.True(guid.Before(uid, ts)),
+			it.True(guid.IsTombstoneOf(ts, uid)),
+			it.Equal(guid.Time(ts), guid.Time(uid)),
+			it.Equal(guid.Node(ts), guid.Node(uid)),
+			it.Equal(guid.Seq(ts), guid.Seq(uid)),
+		).ShouldNot(
+			it.True(guid.IsTombstoneOf(uid, ts)),
+			it.True(guid.IsTombstoneOf(uid, uid)),
+			it.True(guid.IsTombstoneOf(guid.TombstoneOf(ts), ts)),
+		)
+	}
+}
+
+func TestTombstoneOfNext(t *testing.T) {
+	clock := guid.NewClock(guid.WithNodeID(0xa))
+
+	a := guid.G(clock)
+	ts := guid.TombstoneOf(a)
+	b := guid.G(clock)
+
+	it.Then(t).Should(
+		it.True(guid.Before(a, ts)),
+		it.True(guid.Before(ts, b)),
+		it.True(bytes.Compare(guid.Bytes(a), guid.Bytes(ts)) < 0),
+		it.True(bytes.Compare(guid.Bytes(ts), guid.Bytes(b)) < 0),
+		it.True(guid.String(a) < guid.String(ts)),
+		it.True(guid.String(ts) < guid.String(b)),
+		it.Equal(guid.CompareTimeMajor(a, ts), -1),
+		it.Equal(guid.CompareTimeMajor(ts, b), -1),
+	).ShouldNot(
+		it.Equal(ts, b),
+		it.True(guid.IsTombstoneOf(b, a)),
+	)
+}
+
+func TestTombstoneOfWrap(t *testing.T) {
+	for _, uid := range []guid.K{
+		{Hi: 0, Lo: 0xffffffffffffffff},
+		{Hi: 0x1, Lo: 0xffffffffffffffff},
+	} {
+		ts := guid.TombstoneOf(uid)
+
+		it.Then(t).Should(
+			it.True(guid.IsTombstoneOf(ts, uid)),
+			it.True(guid.Before(uid, ts)),
+		).ShouldNot(
+			it.Equal(ts, guid.Nil()),
+		)
+	}
+}
+
+func TestTombstoneOfCodec(t *testing.T) {
+	clock := guid.NewClock(guid.WithNodeID(0xa))
+
+	for _, uid := range []guid.K{guid.G(clock), guid.L(clock)} {
+		ts := guid.TombstoneOf(uid)
+
+		b, errb := guid.FromBytes(guid.Bytes(ts))
+		s, errs := guid.FromString(guid.String(ts))
+		j, _ := json.Marshal(ts)
+
+		var x guid.K
+		errj := json.Unmarshal(j, &x)
+
+		it.Then(t).Should(
+			it.True(guid.IsTombstone(ts)),
+			it.Nil(errb),
+			it.Equal(b, ts),
+			it.Nil(errs),
+			it.Equal(s, ts),
+			it.Nil(errj),
+			it.Equal(x, ts),
+			it.True(guid.Canonical(ts)),
+		).ShouldNot(
+			it.True(guid.IsTombstone(uid)),
+		)
+	}
+
+	_, err := guid.FromBytes(append(guid.Bytes(guid.G(clock)), 1))
+	it.Then(t).ShouldNot(
+		it.Nil(err),
+	)
+}
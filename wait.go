@@ -0,0 +1,86 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// duration of single tick of ⟨𝒕⟩ fraction as it is encoded into identifier
+const tick = time.Duration(1 << bitsSeqDrift)
+
+// The state of ⟨𝒔⟩ sequence within the current tick. The sequence is global
+// for the process, therefore the state is global as well.
+var seqInTick struct {
+	sync.Mutex
+	tick, last uint64
+	full       bool
+}
+
+// admits ⟨𝒕, 𝒔⟩ pair unless sequence rolls over within the tick.
+// The sequence is either ascending or descending (inverse clocks), its
+// direction is inferred from the step between consecutive values.
+func admitSeqInTick(t, seq uint64) bool {
+	seqInTick.Lock()
+	defer seqInTick.Unlock()
+
+	if t != seqInTick.tick {
+		seqInTick.tick = t
+		seqInTick.last = seq
+		seqInTick.full = false
+		return true
+	}
+
+	asc := (seq-seqInTick.last)&0x3fff < 0x2000
+	if seqInTick.full || (asc && seq <= seqInTick.last) || (!asc && seq >= seqInTick.last) {
+		seqInTick.full = true
+		return false
+	}
+
+	seqInTick.last = seq
+	return true
+}
+
+func admitSeqInTickOf(t, seq uint64) bool {
+	return admitSeqInTick(t>>bitsSeqDrift, seq)
+}
+
+// GWait generates globally unique 96-bit k-ordered identifier, same as G.
+// When the ⟨𝒔⟩ sequence would overflow within the current tick, it blocks
+// until the next tick instead of rolling over the sequence. It guarantees
+// that ⟨𝒕, 𝒔⟩ pair is not repeated among identifiers generated by GWait
+// within the process. Other generators (e.g. G, L) are not admitted through
+// the sequence state, their identifiers might repeat the pair. The error is
+// returned if context is cancelled before the clock ticks.
+func GWait(ctx context.Context, clock Chronos, drift ...time.Duration) (K, error) {
+	for {
+		t, l, seq, d, ok := observeIf(clock, drift, admitSeqInTickOf)
+		if ok {
+			return makeG(l, d, t, seq), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return K{}, ctx.Err()
+		case <-time.After(tick):
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestGWait(t *testing.T) {
+	c := guid.NewClock(
+		guid.WithClock(func() uint64 { return 1 << 32 }),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	seq := map[uint64]bool{}
+	for {
+		a, err := guid.GWait(ctx, c)
+		if err != nil {
+			break
+		}
+		seq[guid.Seq(a)] = true
+	}
+
+	it.Then(t).Should(
+		it.True(len(seq) > 0),
+		it.True(len(seq) <= 0x4000),
+	)
+}
+
+func TestGWaitNextTick(t *testing.T) {
+	c := guid.NewClock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a, err := guid.GWait(ctx, c)
+	it.Then(t).Should(it.Nil(err))
+
+	for i := 0; i < 0x8000; i++ {
+		b, err := guid.GWait(ctx, c)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(guid.Before(a, b)),
+		)
+		a = b
+	}
+}
+
+func TestGWaitInverse(t *testing.T) {
+	c := guid.NewClock(guid.WithClockInverse())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a, err := guid.GWait(ctx, c)
+	it.Then(t).Should(it.Nil(err))
+
+	for i := 0; i < 0x8000; i++ {
+		b, err := guid.GWait(ctx, c)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(guid.After(a, b)),
+		)
+		a = b
+	}
+}
@@ -0,0 +1,151 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package webbind decodes identifiers from path parameters of HTTP requests.
+// Malformed identifiers are reported with uniform 400 Bad Request response
+// across services, regardless of web framework.
+//
+//	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+//		uid, err := webbind.ParseParam(r, "id")
+//		if err != nil {
+//			webbind.Failure(w, err)
+//			return
+//		}
+//		...
+//	})
+//
+// Web frameworks are supported through Params interface, the package does
+// not depend on them. echo.Context and gin.Context implement Params as-is,
+// chi is adapted with ParamsFunc.
+package webbind
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fogfish/guid/v2"
+)
+
+// Error is malformed identifier at request parameter
+type Error struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("parameter %s: identifier is not defined", e.Param)
+	}
+	return fmt.Sprintf("parameter %s: invalid identifier %q", e.Param, e.Value)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// StatusCode of HTTP response, the error is always 400 Bad Request
+func (e *Error) StatusCode() int { return http.StatusBadRequest }
+
+// Params is path parameters of web framework request context,
+// e.g. echo.Context or gin.Context
+type Params interface {
+	Param(name string) string
+}
+
+// ParamsFunc adapts ordinary function to Params, e.g. chi router
+//
+//	webbind.ParamsFunc(func(name string) string { return chi.URLParam(r, name) })
+type ParamsFunc func(string) string
+
+func (f ParamsFunc) Param(name string) string { return f(name) }
+
+// ParseParam decodes identifier from path value of net/http request
+// (see http.ServeMux patterns).
+func ParseParam(r *http.Request, name string) (guid.K, error) {
+	return parse(name, r.PathValue(name))
+}
+
+// Parse decodes identifier from path parameters of web framework
+func Parse(p Params, name string) (guid.K, error) {
+	return parse(name, p.Param(name))
+}
+
+// parse decodes identifier strictly, characters outside of the alphabet,
+// non-canonical and Nil values are rejected
+func parse(name, val string) (guid.K, error) {
+	if val == "" {
+		return guid.K{}, &Error{Param: name, Err: errors.New("identifier is not defined")}
+	}
+
+	uid, err := guid.Strict(guid.FromString(val))
+	switch {
+	case err != nil:
+		return guid.K{}, &Error{Param: name, Value: val, Err: err}
+	case uid == guid.Nil():
+		return guid.K{}, &Error{Param: name, Value: val, Err: errors.New("identifier is Nil")}
+	default:
+		return uid, nil
+	}
+}
+
+// Response is JSON body of 400 Bad Request
+type Response struct {
+	Error string `json:"error"`
+	Param string `json:"param,omitempty"`
+}
+
+// Reply returns status code and body of response for the error.
+// It is the building block of framework binders, e.g. with gin
+//
+//	uid, err := webbind.Parse(c, "id")
+//	if err != nil {
+//		c.AbortWithStatusJSON(webbind.Reply(err))
+//		return
+//	}
+func Reply(err error) (int, Response) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.StatusCode(), Response{Error: e.Error(), Param: e.Param}
+	}
+	return http.StatusBadRequest, Response{Error: err.Error()}
+}
+
+// Failure writes 400 Bad Request response for the error
+func Failure(w http.ResponseWriter, err error) {
+	code, body := Reply(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Require is net/http middleware that validates identifiers at path values,
+// requests with malformed identifiers are rejected with 400 Bad Request.
+func Require(names ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, name := range names {
+				if _, err := ParseParam(r, name); err != nil {
+					Failure(w, err)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package webbind_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/v2/webbind"
+	"github.com/fogfish/it/v2"
+)
+
+func TestParseParam(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+
+	r := httptest.NewRequest(http.MethodGet, "/users/"+uid.String(), nil)
+	r.SetPathValue("id", uid.String())
+
+	val, err := webbind.ParseParam(r, "id")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(val, uid),
+	)
+
+	r.SetPathValue("id", "!!!!")
+	_, err = webbind.ParseParam(r, "id")
+
+	var e *webbind.Error
+	it.Then(t).Should(
+		it.True(errors.As(err, &e)),
+		it.Equal(e.Param, "id"),
+		it.Equal(e.Value, "!!!!"),
+		it.Equal(e.StatusCode(), http.StatusBadRequest),
+	)
+
+	for _, val := range []string{
+		"!!!!!!!!!!!!!!!!",
+		"NljBVm51PwMrZR-1",
+		"*!!!!!!!!!!!!!!!!",
+		"................",
+		"...........",
+	} {
+		r.SetPathValue("id", val)
+		_, err = webbind.ParseParam(r, "id")
+		it.Then(t).Should(
+			it.True(errors.As(err, &e)),
+			it.Equal(e.Value, val),
+		)
+	}
+}
+
+func TestParse(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+	params := webbind.ParamsFunc(func(name string) string {
+		if name == "id" {
+			return uid.String()
+		}
+		return ""
+	})
+
+	val, err := webbind.Parse(params, "id")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(val, uid),
+	)
+
+	_, err = webbind.Parse(params, "other")
+	code, body := webbind.Reply(err)
+	it.Then(t).Should(
+		it.Equal(code, http.StatusBadRequest),
+		it.Equal(body.Param, "other"),
+		it.Equal(body.Error, "parameter other: identifier is not defined"),
+	)
+}
+
+func TestRequire(t *testing.T) {
+	uid := guid.G(guid.NewClock(guid.WithNodeID(0xa)))
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{id}",
+		webbind.Require("id")(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+	)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/"+uid.String(), nil))
+	it.Then(t).Should(
+		it.Equal(w.Code, http.StatusNoContent),
+	)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/xxx", nil))
+
+	var body webbind.Response
+	err := json.Unmarshal(w.Body.Bytes(), &body)
+	it.Then(t).Should(
+		it.Equal(w.Code, http.StatusBadRequest),
+		it.Nil(err),
+		it.Equal(body.Param, "id"),
+		it.Equal(body.Error, `parameter id: invalid identifier "xxx"`),
+	)
+}
@@ -0,0 +1,194 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Spec is machine-readable description of identity schema wire format for
+// cross-language implementations. It is encodable as JSON.
+type Spec struct {
+	Version int `json:"version"`
+	// ⟨𝒕⟩ is unix timestamp in nanoseconds shifted right by tick bits
+	TickBits  int            `json:"tick_bits"`
+	Layouts   []SpecLayout   `json:"layouts"`
+	Encodings []SpecEncoding `json:"encodings"`
+}
+
+// SpecLayout is bit layout of identifier for the drift code, fields are
+// listed from the most significant bit.
+type SpecLayout struct {
+	DriftCode int           `json:"drift_code"`
+	DriftBits int           `json:"drift_bits"`
+	DriftStep time.Duration `json:"drift_step_ns"`
+	Global    []SpecField   `json:"global"`
+	Local     []SpecField   `json:"local"`
+}
+
+// SpecField is fraction of identifier
+type SpecField struct {
+	Name string `json:"name"`
+	Bits int    `json:"bits"`
+}
+
+// SpecEncoding is text or binary encoding of identifier
+type SpecEncoding struct {
+	Name        string `json:"name"`
+	Form        string `json:"form"`
+	Alphabet    string `json:"alphabet,omitempty"`
+	BitsPerChar int    `json:"bits_per_char,omitempty"`
+	Length      int    `json:"length"`
+}
+
+// WireSpec returns description of bit layout and encodings of SchemaV1
+func WireSpec() Spec {
+	spec := Spec{
+		Version:  SchemaV1,
+		TickBits: bitsSeqDrift,
+		Encodings: []SpecEncoding{
+			{Name: "bytes", Form: "global", Length: bytesInG},
+			{Name: "bytes", Form: "local", Length: bytesInL},
+			{Name: "string", Form: "global", Alphabet: alphabet, BitsPerChar: 6, Length: 16},
+			{Name: "string", Form: "local", Alphabet: alphabet[:16], BitsPerChar: 4, Length: 16},
+			{Name: "string-local", Form: "local", Alphabet: alphabet, BitsPerChar: 6, Length: charsInL},
+			// base62 omits leading zeros, the length is maximal one
+			{Name: "base62", Form: "global", Alphabet: string(encoder[:]), Length: 17},
+			{Name: "base62", Form: "local", Alphabet: string(encoder[:]), Length: 11},
+		},
+	}
+
+	for i, step := range driftSteps {
+		d := driftZ + i + 1
+		spec.Layouts = append(spec.Layouts, SpecLayout{
+			DriftCode: i + 1,
+			DriftBits: d,
+			DriftStep: step,
+			Global: []SpecField{
+				{Name: "drift", Bits: 3},
+				{Name: "t", Bits: 47 - d},
+				{Name: "node", Bits: 32},
+				{Name: "t", Bits: d},
+				{Name: "seq", Bits: bitsSeq},
+			},
+			Local: []SpecField{
+				{Name: "drift", Bits: 3},
+				{Name: "t", Bits: 47},
+				{Name: "seq", Bits: bitsSeq},
+			},
+		})
+	}
+
+	return spec
+}
+
+// Vector is golden test vector of SchemaV1: ⟨𝒕, 𝒍, 𝒔, 𝒅⟩ inputs and expected
+// outputs of encodings. Ports of identity schema validate against them.
+type Vector struct {
+	UnixNano int64         `json:"unix_nano"`
+	Node     uint32        `json:"node"`
+	Seq      uint16        `json:"seq"`
+	Drift    time.Duration `json:"drift_ns"`
+	Global   bool          `json:"global"`
+	Hex      string        `json:"hex"`
+	String   string        `json:"string"`
+	Local    string        `json:"string_local"`
+	Base62   string        `json:"base62"`
+}
+
+var vectors = []Vector{
+	{UnixNano: 1717243200000000000, Node: 0xa, Seq: 0x5, Drift: 549 * time.Second, Global: true, Hex: "82fa9bf0000000a2f21e8005", String: "VjeQw....9Am6c.4", Local: "7AuazAm6c.4", Base62: "qiZB2PRpV4tYIjsD"},
+	{UnixNano: 0, Node: 0x0, Seq: 0x0, Drift: 68 * time.Second, Global: true, Hex: "200000000000000000000000", String: "7...............", Local: "1..........", Base62: "CsYXGgXQRURtExA8"},
+	{UnixNano: 2147483648000000000, Node: 0xffffffff, Seq: 0x3fff, Drift: 4398 * time.Second, Global: true, Hex: "e3b9acffffffffa000003fff", String: "svagzzzzzu...2zz", Local: "DDtf9...2zz", Base62: "1TerY6ZbLCjPuedzz"},
+	{UnixNano: 1717243200000000000, Node: 0x0, Seq: 0x5, Drift: 549 * time.Second, Global: false, Hex: "82fa9bf2f21e8005", String: "71E98AE1E10D7..4", Local: "7AuazAm6c.4", Base62: "BFCGThRb965"},
+	{UnixNano: 9223372036000000000, Node: 0x89abcdef, Seq: 0x1234, Drift: 137 * time.Second, Global: true, Hex: "4ffffffe26af37bff9a19234", String: "IzzzzXPjCvztcO7o", Local: "3zzzzztcO7o", Base62: "WCOLA3i7MATJqVgS"},
+}
+
+// TestVectors returns golden test vectors of SchemaV1
+func TestVectors() []Vector {
+	return slices.Clone(vectors)
+}
+
+// SelfTest executes round trips of golden vectors through the layout and all
+// encodings. Application runs it at startup to detect incompatible builds.
+func SelfTest() error {
+	var errs []error
+	for i, v := range vectors {
+		if err := v.check(); err != nil {
+			errs = append(errs, fmt.Errorf("guid: self-test vector %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (v Vector) check() error {
+	d := driftInBits([]time.Duration{v.Drift})
+	uid := makeL(d, uint64(v.UnixNano), uint64(v.Seq))
+	if v.Global {
+		uid = makeG(uint64(v.Node), d, uint64(v.UnixNano), uint64(v.Seq))
+	}
+
+	if x := Decode(uid); x.T.UnixNano() != v.UnixNano>>bitsSeqDrift<<bitsSeqDrift ||
+		x.Node != v.Node || x.Seq != v.Seq || x.Drift != v.Drift || x.Global != v.Global {
+		return fmt.Errorf("layout %+v", x)
+	}
+
+	bytes, err := hex.DecodeString(v.Hex)
+	if err != nil {
+		return err
+	}
+
+	codecs := []struct {
+		name string
+		enc  string
+		want string
+		dec  func() (K, error)
+		orig K
+	}{
+		{"bytes", hex.EncodeToString(Bytes(uid)), v.Hex, func() (K, error) { return FromBytes(bytes) }, uid},
+		{"string", String(uid), v.String, func() (K, error) {
+			if v.Global {
+				return FromStringG(v.String)
+			}
+			return FromStringL(v.String)
+		}, uid},
+		{"string-local", StringLocal(uid), v.Local, func() (K, error) { return FromStringLocal(v.Local) }, ToL(uid)},
+		{"base62", Base62(uid), v.Base62, func() (K, error) { return FromBase62(v.Base62) }, uid},
+	}
+
+	for _, c := range codecs {
+		if c.enc != c.want {
+			return fmt.Errorf("%s encodes %s, expected %s", c.name, c.enc, c.want)
+		}
+
+		x, err := c.dec()
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.name, err)
+		}
+		if x != c.orig {
+			return fmt.Errorf("%s decodes %v, expected %v", c.name, x, c.orig)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestWireSpec(t *testing.T) {
+	spec := guid.WireSpec()
+
+	it.Then(t).Should(
+		it.Equal(spec.Version, guid.SchemaV1),
+		it.Equal(spec.TickBits, 17),
+		it.Equal(len(spec.Layouts), 7),
+	)
+
+	for _, layout := range spec.Layouts {
+		global, local := 0, 0
+		for _, f := range layout.Global {
+			global += f.Bits
+		}
+		for _, f := range layout.Local {
+			local += f.Bits
+		}
+
+		it.Then(t).Should(
+			it.Equal(global, 96),
+			it.Equal(local, 64),
+		)
+	}
+
+	_, err := json.Marshal(spec)
+	it.Then(t).Should(it.Nil(err))
+}
+
+func TestSelfTest(t *testing.T) {
+	it.Then(t).Should(
+		it.Nil(guid.SelfTest()),
+	)
+}
+
+func TestTestVectors(t *testing.T) {
+	vectors := guid.TestVectors()
+	it.Then(t).Should(
+		it.True(len(vectors) > 0),
+	)
+
+	for _, v := range vectors {
+		uid, err := guid.Compose(time.Unix(0, v.UnixNano), v.Node, v.Seq, v.Drift)
+		if !v.Global {
+			uid = guid.ToL(uid)
+		}
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(hex.EncodeToString(guid.Bytes(uid)), v.Hex),
+			it.Equal(guid.String(uid), v.String),
+			it.Equal(guid.StringLocal(uid), v.Local),
+			it.Equal(guid.Base62(uid), v.Base62),
+		)
+	}
+
+	// vectors are copied
+	vectors[0].Hex = ""
+	it.Then(t).Should(
+		it.Nil(guid.SelfTest()),
+	)
+}
@@ -0,0 +1,74 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid
+
+import "io"
+
+// writers that expose unused capacity of internal buffer (e.g. bufio.Writer)
+type availableBuffer interface {
+	AvailableBuffer() []byte
+}
+
+// WriteString writes lexicographically sortable string of k-ordered value
+// (see String) into the writer. Writers exposing AvailableBuffer (e.g.
+// bufio.Writer, bytes.Buffer) are written without allocations.
+func WriteString(w io.Writer, uid K) (int, error) {
+	if ab, ok := w.(availableBuffer); ok {
+		return w.Write(appendString(ab.AvailableBuffer(), uid))
+	}
+
+	return w.Write(appendString(make([]byte, 0, 16), uid))
+}
+
+func appendString(dst []byte, uid K) []byte {
+	var enc [16]byte
+	encodeString(uid, &enc)
+	dst = append(dst, enc[:]...)
+	if uid.Hi&tombstoneBit != 0 {
+		// delete-marker is suffixed with the lowest char (see TombstoneOf)
+		dst = append(dst, alphabet[0])
+	}
+	return dst
+}
+
+// WriteBytes writes binary form of k-ordered value (see Bytes) into the
+// writer. Writers exposing AvailableBuffer are written without allocations.
+func WriteBytes(w io.Writer, uid K) (int, error) {
+	if ab, ok := w.(availableBuffer); ok {
+		return w.Write(appendBytes(ab.AvailableBuffer(), uid))
+	}
+
+	return w.Write(appendBytes(make([]byte, 0, bytesInG), uid))
+}
+
+func appendBytes(dst []byte, uid K) []byte {
+	var buf [bytesInG]byte
+
+	if uid.Hi == 0 {
+		split(0, uid.Lo, 64, 8, buf[:bytesInL])
+		return append(dst, buf[:bytesInL]...)
+	}
+
+	split(uid.Hi, uid.Lo, 96, 8, buf[:])
+	dst = append(dst, buf[:]...)
+	if uid.Hi&tombstoneBit != 0 {
+		dst = append(dst, 0)
+	}
+	return dst
+}
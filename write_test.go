@@ -0,0 +1,71 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package guid_test
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/it/v2"
+)
+
+func TestWrite(t *testing.T) {
+	g := guid.G(guid.Clock)
+	l := guid.L(guid.Clock)
+
+	t.Run("String", func(t *testing.T) {
+		var sb strings.Builder
+		n, err := guid.WriteString(&sb, g)
+		guid.WriteString(&sb, l)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(n, 16),
+			it.Equal(sb.String(), guid.String(g)+guid.String(l)),
+		)
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := guid.WriteBytes(&buf, g)
+		guid.WriteBytes(&buf, l)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(n, 12),
+			it.Equal(buf.String(), string(guid.Bytes(g))+string(guid.Bytes(l))),
+		)
+	})
+
+	t.Run("NoAlloc", func(t *testing.T) {
+		w := bufio.NewWriter(io.Discard)
+		allocs := testing.AllocsPerRun(100, func() {
+			guid.WriteString(w, g)
+			guid.WriteBytes(w, g)
+		})
+
+		it.Then(t).Should(
+			it.Equal(allocs, 0),
+		)
+	})
+}
@@ -0,0 +1,13 @@
+module github.com/fogfish/guid/zapk
+
+go 1.23
+
+require (
+	github.com/fogfish/guid/v2 v2.0.0
+	github.com/fogfish/it/v2 v2.0.1
+	go.uber.org/zap v1.28.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
+
+replace github.com/fogfish/guid/v2 => ../
@@ -0,0 +1,42 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+// Package zapk implements zap logging adapter for k-ordered values.
+package zapk
+
+import (
+	"github.com/fogfish/guid/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// K is k-ordered value, which implements zapcore.ObjectMarshaler. It emits
+// lexicographically sortable string and decoded ⟨𝒕⟩ timestamp.
+type K guid.K
+
+// MarshalLogObject encodes k-ordered value as structured log object
+func (uid K) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("id", guid.String(guid.K(uid)))
+	enc.AddTime("t", guid.EpochT(guid.K(uid)))
+	return nil
+}
+
+// Field constructs zap field from k-ordered value
+func Field(key string, uid guid.K) zap.Field {
+	return zap.Object(key, K(uid))
+}
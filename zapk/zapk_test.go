@@ -0,0 +1,43 @@
+/*
+
+  Copyright 2012 Dmitry Kolesnikov, All Rights Reserved
+
+  Licensed under the Apache License, Version 2.0 (the "License");
+  you may not use this file except in compliance with the License.
+  You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+  Unless required by applicable law or agreed to in writing, software
+  distributed under the License is distributed on an "AS IS" BASIS,
+  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+  See the License for the specific language governing permissions and
+  limitations under the License.
+
+*/
+
+package zapk_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fogfish/guid/v2"
+	"github.com/fogfish/guid/zapk"
+	"github.com/fogfish/it/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestField(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	a := guid.G(guid.NewClock())
+
+	zap.New(core).Info("event", zapk.Field("uid", a))
+
+	obj := logs.All()[0].ContextMap()["uid"].(map[string]any)
+	it.Then(t).Should(
+		it.Equal(obj["id"].(string), guid.String(a)),
+		it.Equal(obj["t"].(time.Time).UnixNano(), guid.EpochT(a).UnixNano()),
+	)
+}